@@ -1,6 +1,9 @@
 package admin
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/W1ndys/easy-qfnu-api-go/common/response"
 	"github.com/W1ndys/easy-qfnu-api-go/internal/config"
 	"github.com/gin-gonic/gin"
@@ -8,17 +11,30 @@ import (
 
 // GetConfig 获取所有配置
 func GetConfig(c *gin.Context) {
+	minCredits, maxCredits := config.GetSelectionCreditLimits()
 	response.Success(c, gin.H{
-		"site_access_enabled": config.IsSiteAccessEnabled(),
-		"token_expire_hours":  config.GetTokenExpireHours(),
+		"site_access_enabled":   config.IsSiteAccessEnabled(),
+		"token_expire_hours":    config.GetTokenExpireHours(),
+		"selection_min_credits": minCredits,
+		"selection_max_credits": maxCredits,
+
+		"recommendation_rate_limit":       config.GetRecommendationRateLimit(),
+		"recommendation_sensitive_words":  config.GetRecommendationSensitiveWords(),
+		"recommendation_sensitive_action": config.GetRecommendationSensitiveAction(),
 	})
 }
 
 type UpdateConfigRequest struct {
-	SiteAccessEnabled  *bool   `json:"site_access_enabled"`
-	SiteAccessPassword *string `json:"site_access_password"`
-	AdminPassword      *string `json:"admin_password"`
-	TokenExpireHours   *string `json:"token_expire_hours"`
+	SiteAccessEnabled  *bool    `json:"site_access_enabled"`
+	SiteAccessPassword *string  `json:"site_access_password"`
+	AdminPassword      *string  `json:"admin_password"`
+	TokenExpireHours   *string  `json:"token_expire_hours"`
+	SelectionMinCredit *float64 `json:"selection_min_credits"`
+	SelectionMaxCredit *float64 `json:"selection_max_credits"`
+
+	RecommendationRateLimit       *int      `json:"recommendation_rate_limit"`
+	RecommendationSensitiveWords  *[]string `json:"recommendation_sensitive_words"`
+	RecommendationSensitiveAction *string   `json:"recommendation_sensitive_action"`
 }
 
 // UpdateConfig 更新配置
@@ -29,6 +45,24 @@ func UpdateConfig(c *gin.Context) {
 		return
 	}
 
+	if (req.SelectionMinCredit != nil && *req.SelectionMinCredit < 0) ||
+		(req.SelectionMaxCredit != nil && *req.SelectionMaxCredit < 0) {
+		response.Fail(c, "学分限制不能为负数")
+		return
+	}
+
+	if req.RecommendationRateLimit != nil && *req.RecommendationRateLimit < 0 {
+		response.Fail(c, "提交频率限制不能为负数")
+		return
+	}
+
+	if req.RecommendationSensitiveAction != nil &&
+		*req.RecommendationSensitiveAction != config.SensitiveActionFlag &&
+		*req.RecommendationSensitiveAction != config.SensitiveActionReject {
+		response.Fail(c, "敏感词处理方式只能是 flag 或 reject")
+		return
+	}
+
 	if req.SiteAccessEnabled != nil {
 		if *req.SiteAccessEnabled {
 			config.Set(config.KeySiteAccessEnabled, "true")
@@ -49,5 +83,25 @@ func UpdateConfig(c *gin.Context) {
 		config.Set(config.KeyTokenExpireHours, *req.TokenExpireHours)
 	}
 
+	if req.SelectionMinCredit != nil {
+		config.Set(config.KeySelectionMinCredit, strconv.FormatFloat(*req.SelectionMinCredit, 'f', -1, 64))
+	}
+
+	if req.SelectionMaxCredit != nil {
+		config.Set(config.KeySelectionMaxCredit, strconv.FormatFloat(*req.SelectionMaxCredit, 'f', -1, 64))
+	}
+
+	if req.RecommendationRateLimit != nil {
+		config.Set(config.KeyRecommendationRateLimit, strconv.Itoa(*req.RecommendationRateLimit))
+	}
+
+	if req.RecommendationSensitiveWords != nil {
+		config.Set(config.KeyRecommendationSensitiveWords, strings.Join(*req.RecommendationSensitiveWords, "\n"))
+	}
+
+	if req.RecommendationSensitiveAction != nil {
+		config.Set(config.KeyRecommendationSensitiveAction, *req.RecommendationSensitiveAction)
+	}
+
 	response.Success(c, gin.H{})
 }
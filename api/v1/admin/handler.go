@@ -27,7 +27,7 @@ func Login(c *gin.Context) {
 	}
 
 	expireHours := 24
-	token := crypto.GenerateToken("admin", expireHours)
+	token := crypto.GenerateTokenFor("admin", config.AdminAccount, expireHours)
 	expireAt := time.Now().Add(time.Hour * time.Duration(expireHours)).Unix()
 
 	c.SetCookie(middleware.AdminTokenCookie, token, expireHours*3600, "/", "", false, true)
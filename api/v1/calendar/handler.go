@@ -0,0 +1,140 @@
+package calendar
+
+import (
+	"errors"
+	"time"
+
+	"github.com/W1ndys/easy-qfnu-api-go/common/response"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+	services "github.com/W1ndys/easy-qfnu-api-go/services/calendar"
+	"github.com/gin-gonic/gin"
+)
+
+// GetCalendar 查询某一天所在的学期、教学周以及当天是否上课
+func GetCalendar(c *gin.Context) {
+	var req model.CalendarRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "查询参数错误，请检查后重试")
+		return
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.Date, time.Local)
+		if err != nil {
+			response.FailWithCode(c, response.CodeInvalidParam, "日期格式错误，应为 2006-01-02")
+			return
+		}
+		date = parsed
+	}
+
+	day, err := services.Resolve(date)
+	if err != nil {
+		response.Fail(c, "查询校历失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, day)
+}
+
+// GetTerms 获取校历学期列表（管理员）
+func GetTerms(c *gin.Context) {
+	list, err := services.ListTerms()
+	if err != nil {
+		response.Fail(c, "获取失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// SaveTerm 新增或更新校历学期（管理员）
+func SaveTerm(c *gin.Context) {
+	var req model.CalendarTermRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	if err := services.SaveTerm(req); err != nil {
+		failWithCalendarError(c, "保存失败", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "保存成功"})
+}
+
+// DeleteTerm 删除校历学期（管理员）
+func DeleteTerm(c *gin.Context) {
+	var req model.CalendarDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	if err := services.DeleteTerm(req.Key); err != nil {
+		failWithCalendarError(c, "删除失败", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "删除成功"})
+}
+
+// GetSpecialDays 获取放假 / 调休补课日期列表（管理员）
+func GetSpecialDays(c *gin.Context) {
+	list, err := services.ListSpecialDays()
+	if err != nil {
+		response.Fail(c, "获取失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// SaveSpecialDay 新增或更新放假 / 调休补课日期（管理员）
+func SaveSpecialDay(c *gin.Context) {
+	var req model.CalendarSpecialDayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	if err := services.SaveSpecialDay(req); err != nil {
+		failWithCalendarError(c, "保存失败", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "保存成功"})
+}
+
+// DeleteSpecialDay 删除放假 / 调休补课日期（管理员）
+func DeleteSpecialDay(c *gin.Context) {
+	var req model.CalendarDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	if err := services.DeleteSpecialDay(req.Key); err != nil {
+		failWithCalendarError(c, "删除失败", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "删除成功"})
+}
+
+// failWithCalendarError 将校历服务的错误映射为响应
+func failWithCalendarError(c *gin.Context, action string, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		response.FailWithCode(c, response.CodeResourceNotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidTerm),
+		errors.Is(err, services.ErrInvalidDate),
+		errors.Is(err, services.ErrInvalidWeeks),
+		errors.Is(err, services.ErrInvalidDayType),
+		errors.Is(err, services.ErrMissingFollowTo):
+		response.FailWithCode(c, response.CodeInvalidParam, err.Error())
+	default:
+		response.Fail(c, action+": "+err.Error())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strconv"
 
+	"github.com/W1ndys/easy-qfnu-api-go/common/request"
 	"github.com/W1ndys/easy-qfnu-api-go/common/response"
 	"github.com/W1ndys/easy-qfnu-api-go/model"
 	services "github.com/W1ndys/easy-qfnu-api-go/services/course_recommendation"
@@ -18,7 +19,7 @@ func Query(c *gin.Context) {
 		return
 	}
 
-	list, err := services.Query(req.Keyword)
+	list, err := services.Query(req)
 	if err != nil {
 		response.Fail(c, "查询失败: "+err.Error())
 		return
@@ -35,18 +36,49 @@ func Recommend(c *gin.Context) {
 		return
 	}
 
-	recommendationTime, err := services.Recommend(req)
+	recommendationTime, statusToken, err := services.Recommend(req, clientOf(c))
 	if err != nil {
-		response.Fail(c, "提交失败: "+err.Error())
+		switch {
+		case errors.Is(err, services.ErrRateLimited):
+			response.FailWithCode(c, response.CodeTooManyRequests, err.Error())
+		case errors.Is(err, services.ErrDuplicate),
+			errors.Is(err, services.ErrSensitiveContent),
+			errors.Is(err, services.ErrInvalidEntityName):
+			response.FailWithCode(c, response.CodeInvalidParam, err.Error())
+		default:
+			response.Fail(c, "提交失败: "+err.Error())
+		}
 		return
 	}
 
 	response.Success(c, model.CourseRecommendationRecommendResponse{
 		Message:            "推荐成功",
 		RecommendationTime: recommendationTime,
+		StatusToken:        statusToken,
 	})
 }
 
+// Status 根据提交时返回的凭证查询投稿审核状态接口
+func Status(c *gin.Context) {
+	var req model.CourseRecommendationStatusRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "请提供查询凭证")
+		return
+	}
+
+	resp, err := services.GetStatus(req.Token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidStatusToken) {
+			response.FailWithCode(c, response.CodeResourceNotFound, err.Error())
+			return
+		}
+		response.Fail(c, "查询失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, resp)
+}
+
 // Review 审核课程推荐接口（管理员）
 func Review(c *gin.Context) {
 	var req model.CourseRecommendationReviewRequest
@@ -55,13 +87,16 @@ func Review(c *gin.Context) {
 		return
 	}
 
-	err := services.Review(req.RecommendationID, req.IsVisible)
+	err := services.Review(req, request.GetCurrentAdmin(c))
 	if err != nil {
-		if errors.Is(err, services.ErrNotFound) {
+		switch {
+		case errors.Is(err, services.ErrNotFound):
 			response.FailWithCode(c, response.CodeResourceNotFound, "推荐记录不存在")
-			return
+		case errors.Is(err, services.ErrRejectReasonRequired):
+			response.FailWithCode(c, response.CodeInvalidParam, err.Error())
+		default:
+			response.Fail(c, "审核失败: "+err.Error())
 		}
-		response.Fail(c, "审核失败: "+err.Error())
 		return
 	}
 
@@ -89,11 +124,11 @@ func Update(c *gin.Context) {
 	response.Success(c, gin.H{"message": "更新成功"})
 }
 
-// GetAll 获取所有课程推荐（管理员）
+// GetAll 获取所有课程推荐（管理员），status 可选 pending / approved / rejected / hidden / flagged / all
 func GetAll(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "6"))
-	status := c.DefaultQuery("status", "pending")
+	status := c.DefaultQuery("status", services.StatusPending)
 
 	list, total, err := services.GetAll(page, pageSize, status)
 	if err != nil {
@@ -131,3 +166,192 @@ func Delete(c *gin.Context) {
 
 	response.Success(c, gin.H{"message": "删除成功"})
 }
+
+// GetEntities 获取规范课程 / 教师列表（管理员）
+func GetEntities(c *gin.Context) {
+	var req model.RecommendationEntityListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	list, err := services.ListEntities(req.Kind, req.Keyword)
+	if err != nil {
+		response.Fail(c, "获取失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// MergeEntities 合并重复的课程 / 教师（管理员）
+func MergeEntities(c *gin.Context) {
+	var req model.RecommendationEntityMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	if err := services.MergeEntities(req); err != nil {
+		failWithEntityError(c, "合并失败", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "合并成功"})
+}
+
+// RenameEntity 重命名课程 / 教师（管理员）
+func RenameEntity(c *gin.Context) {
+	var req model.RecommendationEntityRenameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	if err := services.RenameEntity(req); err != nil {
+		failWithEntityError(c, "重命名失败", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "重命名成功"})
+}
+
+// AddEntityAlias 为课程 / 教师添加别名（管理员）
+func AddEntityAlias(c *gin.Context) {
+	var req model.RecommendationEntityAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	if err := services.AddAlias(req); err != nil {
+		failWithEntityError(c, "添加别名失败", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "添加成功"})
+}
+
+// RemoveEntityAlias 删除课程 / 教师的别名（管理员）
+func RemoveEntityAlias(c *gin.Context) {
+	var req model.RecommendationEntityAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	if err := services.RemoveAlias(req); err != nil {
+		failWithEntityError(c, "删除别名失败", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "删除成功"})
+}
+
+// failWithEntityError 将实体相关的业务错误映射为响应
+func failWithEntityError(c *gin.Context, action string, err error) {
+	switch {
+	case errors.Is(err, services.ErrEntityNotFound):
+		response.FailWithCode(c, response.CodeResourceNotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidEntityName),
+		errors.Is(err, services.ErrAliasTaken),
+		errors.Is(err, services.ErrAliasIsName),
+		errors.Is(err, services.ErrMergeIntoSelf):
+		response.FailWithCode(c, response.CodeInvalidParam, err.Error())
+	default:
+		response.Fail(c, action+": "+err.Error())
+	}
+}
+
+// MarkHelpful 标记推荐有用接口，同一客户端重复标记只计一次
+func MarkHelpful(c *gin.Context) {
+	var req model.RecommendationHelpfulRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	resp, err := services.MarkHelpful(req.RecommendationID, clientOf(c))
+	if err != nil {
+		failWithFeedbackError(c, "操作失败", err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Report 举报推荐接口
+func Report(c *gin.Context) {
+	var req model.RecommendationReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "请填写举报原因 (不超过 200 字)")
+		return
+	}
+
+	if err := services.Report(req, clientOf(c)); err != nil {
+		failWithFeedbackError(c, "举报失败", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "举报成功，我们会尽快处理"})
+}
+
+// GetReports 获取举报列表（管理员）
+func GetReports(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	status := c.DefaultQuery("status", services.ReportStatusOpen)
+
+	list, total, err := services.GetReports(page, pageSize, status)
+	if err != nil {
+		response.Fail(c, "获取失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"list":      list,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+// ResolveReport 处理举报（管理员）
+func ResolveReport(c *gin.Context) {
+	var req model.RecommendationReportResolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "参数错误")
+		return
+	}
+
+	if err := services.ResolveReport(req, request.GetCurrentAdmin(c)); err != nil {
+		failWithFeedbackError(c, "处理失败", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "处理成功"})
+}
+
+// clientOf 获取提交者的 IP 和客户端指纹
+func clientOf(c *gin.Context) model.RecommendationClient {
+	return model.RecommendationClient{
+		IP:          c.ClientIP(),
+		Fingerprint: request.GetClientFingerprint(c),
+	}
+}
+
+// failWithFeedbackError 将投票、举报相关的业务错误映射为响应
+func failWithFeedbackError(c *gin.Context, action string, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound), errors.Is(err, services.ErrReportNotFound):
+		response.FailWithCode(c, response.CodeResourceNotFound, err.Error())
+	case errors.Is(err, services.ErrRateLimited):
+		response.FailWithCode(c, response.CodeTooManyRequests, err.Error())
+	case errors.Is(err, services.ErrInvalidReportReason),
+		errors.Is(err, services.ErrAlreadyReported),
+		errors.Is(err, services.ErrReportResolved):
+		response.FailWithCode(c, response.CodeInvalidParam, err.Error())
+	default:
+		response.Fail(c, action+": "+err.Error())
+	}
+}
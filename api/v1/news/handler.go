@@ -0,0 +1,42 @@
+package news
+
+import (
+	"errors"
+
+	"github.com/W1ndys/easy-qfnu-api-go/common/response"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+	services "github.com/W1ndys/easy-qfnu-api-go/services/news"
+	"github.com/gin-gonic/gin"
+)
+
+// GetNewsList 分页查询教务处通知公告
+func GetNewsList(c *gin.Context) {
+	var req model.NewsListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "查询参数错误，请检查后重试")
+		return
+	}
+
+	data, err := services.List(req)
+	if err != nil {
+		response.Fail(c, "获取通知公告失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, data)
+}
+
+// GetNewsDetail 获取教务处通知公告详情
+func GetNewsDetail(c *gin.Context) {
+	data, err := services.Detail(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			response.FailWithCode(c, response.CodeResourceNotFound, "通知不存在")
+			return
+		}
+		response.FailWithCode(c, response.CodeTargetError, "获取通知详情失败: "+err.Error())
+		return
+	}
+
+	response.Success(c, data)
+}
@@ -0,0 +1,44 @@
+package zhjw
+
+import (
+	"errors"
+
+	"github.com/W1ndys/easy-qfnu-api-go/common/request"
+	"github.com/W1ndys/easy-qfnu-api-go/common/response"
+	zhjwService "github.com/W1ndys/easy-qfnu-api-go/services/zhjw"
+	"github.com/gin-gonic/gin"
+)
+
+// GetEvaluationTasks 获取评教任务列表 (只读)
+func GetEvaluationTasks(c *gin.Context) {
+
+	// 获取参数，能放行到这里，说明已经通过鉴权中间件检查
+	Authorization := request.GetCurrentUserAuthorization(c)
+
+	// 调用业务逻辑 (Service 层)
+	data, err := zhjwService.FetchEvaluationTasks(Authorization)
+	// 处理业务结果
+	// 如果有错误，返回错误信息
+	if errors.Is(err, zhjwService.ErrCookieExpired) {
+		response.CookieExpired(c)
+		return
+	} else if errors.Is(err, zhjwService.ErrResourceNotFound) {
+		response.ResourceNotFound(c)
+		return
+	} else if err != nil {
+		response.FailWithCode(c, 1, "获取评教任务失败: "+err.Error())
+		return
+	}
+	response.Success(c, data)
+
+}
+
+// evaluationPending 返回 "请先完成评教" 错误，并尽量附带未完成的评教任务
+func evaluationPending(c *gin.Context, authorization string) {
+	data, err := zhjwService.FetchEvaluationTasks(authorization)
+	if err != nil {
+		response.FailWithCode(c, response.CodeEvaluationPending, response.GetMsg(response.CodeEvaluationPending))
+		return
+	}
+	response.FailWithData(c, response.CodeEvaluationPending, response.GetMsg(response.CodeEvaluationPending), data)
+}
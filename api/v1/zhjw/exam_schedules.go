@@ -2,6 +2,8 @@ package zhjw
 
 import (
 	"errors"
+	"net/http"
+	"time"
 
 	"github.com/W1ndys/easy-qfnu-api-go/common/request"
 	"github.com/W1ndys/easy-qfnu-api-go/common/response"
@@ -41,3 +43,42 @@ func GetExamSchedules(c *gin.Context) {
 	response.Success(c, data)
 
 }
+
+// GetExamCalendar 导出考试安排为 iCalendar (.ics) 文件
+func GetExamCalendar(c *gin.Context) {
+
+	// 获取参数，能放行到这里，说明已经通过鉴权中间件检查
+	Authorization := request.GetCurrentUserAuthorization(c)
+
+	// 绑定查询参数到结构体
+	var req model.ExamCalendarRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "查询参数错误，请检查后重试")
+		return
+	}
+
+	alarms, err := zhjwService.ParseAlarmOffsets(req.Alarms)
+	if err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+
+	// 调用业务逻辑 (Service 层)
+	exams, err := zhjwService.FetchExamSchedules(Authorization, req.Term)
+	// 处理业务结果
+	// 如果有错误，返回错误信息
+	if errors.Is(err, zhjwService.ErrCookieExpired) {
+		response.CookieExpired(c)
+		return
+	} else if errors.Is(err, zhjwService.ErrResourceNotFound) {
+		response.ResourceNotFound(c)
+		return
+	} else if err != nil {
+		response.FailWithCode(c, 1, "获取考试安排失败: "+err.Error())
+		return
+	}
+
+	data := zhjwService.BuildExamCalendar(exams, alarms, time.Now())
+	c.Header("Content-Disposition", `attachment; filename="exams.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
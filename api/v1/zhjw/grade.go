@@ -34,6 +34,9 @@ func GetGradeList(c *gin.Context) {
 	} else if errors.Is(err, zhjwService.ErrResourceNotFound) {
 		response.ResourceNotFound(c)
 		return
+	} else if errors.Is(err, zhjwService.ErrEvaluationPending) {
+		evaluationPending(c, Authorization)
+		return
 	} else if err != nil {
 		response.FailWithCode(c, 1, "获取成绩失败: "+err.Error())
 		return
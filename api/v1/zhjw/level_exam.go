@@ -0,0 +1,34 @@
+package zhjw
+
+import (
+	"errors"
+
+	"github.com/W1ndys/easy-qfnu-api-go/common/request"
+	"github.com/W1ndys/easy-qfnu-api-go/common/response"
+	zhjwService "github.com/W1ndys/easy-qfnu-api-go/services/zhjw"
+	"github.com/gin-gonic/gin"
+)
+
+// GetLevelExamScores 查询等级考试成绩 (四六级、计算机等级考试等)
+func GetLevelExamScores(c *gin.Context) {
+
+	// 获取参数，能放行到这里，说明已经通过鉴权中间件检查
+	Authorization := request.GetCurrentUserAuthorization(c)
+
+	// 调用业务逻辑 (Service 层)
+	data, err := zhjwService.FetchLevelExamScores(Authorization)
+	// 处理业务结果
+	// 如果有错误，返回错误信息
+	if errors.Is(err, zhjwService.ErrCookieExpired) {
+		response.CookieExpired(c)
+		return
+	} else if errors.Is(err, zhjwService.ErrResourceNotFound) {
+		response.ResourceNotFound(c)
+		return
+	} else if err != nil {
+		response.FailWithCode(c, 1, "获取等级考试成绩失败: "+err.Error())
+		return
+	}
+	response.Success(c, data)
+
+}
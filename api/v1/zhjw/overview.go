@@ -0,0 +1,56 @@
+package zhjw
+
+import (
+	"errors"
+
+	"github.com/W1ndys/easy-qfnu-api-go/common/request"
+	"github.com/W1ndys/easy-qfnu-api-go/common/response"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+	zhjwService "github.com/W1ndys/easy-qfnu-api-go/services/zhjw"
+	"github.com/gin-gonic/gin"
+)
+
+// GetOverview 一次性获取首页所需的绩点、今日课程、考试安排和选课结果
+// 部分内容获取失败时在对应部分中返回错误，不影响其他部分
+func GetOverview(c *gin.Context) {
+
+	// 获取参数，能放行到这里，说明已经通过鉴权中间件检查
+	Authorization := request.GetCurrentUserAuthorization(c)
+
+	// 调用业务逻辑 (Service 层)
+	data, err := zhjwService.FetchOverview(Authorization)
+	if errors.Is(err, zhjwService.ErrCookieExpired) {
+		response.CookieExpired(c)
+		return
+	} else if err != nil {
+		response.FailWithCode(c, 1, "获取个人概览失败: "+err.Error())
+		return
+	}
+	response.Success(c, model.OverviewResponse{
+		GPA:       overviewSection(data.GPA, data.GPAErr),
+		Today:     overviewSection(data.Today, data.TodayErr),
+		Exams:     overviewSection(data.Exams, data.ExamsErr),
+		Selection: overviewSection(data.Selection, data.SelectionErr),
+	})
+
+}
+
+// overviewSection 将单个部分的结果包装为概览部分，错误码与各独立接口保持一致
+func overviewSection[T any](data *T, err error) model.OverviewSection[T] {
+	if err == nil {
+		return model.OverviewSection[T]{Status: model.OverviewStatusOK, Data: data}
+	}
+
+	section := model.OverviewSection[T]{Status: model.OverviewStatusError, Error: err.Error()}
+	switch {
+	case errors.Is(err, zhjwService.ErrCookieExpired):
+		section.Code = response.CodeAuthExpired
+		section.Error = response.GetMsg(response.CodeAuthExpired)
+	case errors.Is(err, zhjwService.ErrEvaluationPending):
+		section.Code = response.CodeEvaluationPending
+		section.Error = response.GetMsg(response.CodeEvaluationPending)
+	default:
+		section.Code = response.CodeTargetError
+	}
+	return section
+}
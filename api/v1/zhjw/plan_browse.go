@@ -0,0 +1,117 @@
+package zhjw
+
+import (
+	"errors"
+
+	"github.com/W1ndys/easy-qfnu-api-go/common/request"
+	"github.com/W1ndys/easy-qfnu-api-go/common/response"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+	zhjwService "github.com/W1ndys/easy-qfnu-api-go/services/zhjw"
+	"github.com/gin-gonic/gin"
+)
+
+// GetPlanOptions 获取培养方案查询可选的年级和专业
+func GetPlanOptions(c *gin.Context) {
+
+	// 获取参数，能放行到这里，说明已经通过鉴权中间件检查
+	Authorization := request.GetCurrentUserAuthorization(c)
+
+	// 调用业务逻辑 (Service 层)
+	data, err := zhjwService.FetchPlanOptions(Authorization)
+	if errors.Is(err, zhjwService.ErrCookieExpired) {
+		response.CookieExpired(c)
+		return
+	} else if err != nil {
+		response.FailWithCode(c, 1, "获取培养方案查询选项失败: "+err.Error())
+		return
+	}
+	response.Success(c, data)
+
+}
+
+// SearchPlans 按年级和专业查询培养方案列表
+func SearchPlans(c *gin.Context) {
+
+	// 获取参数，能放行到这里，说明已经通过鉴权中间件检查
+	Authorization := request.GetCurrentUserAuthorization(c)
+
+	// 绑定查询参数到结构体
+	var req model.PlanSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "查询参数错误，请检查后重试")
+		return
+	}
+
+	// 调用业务逻辑 (Service 层)
+	data, err := zhjwService.SearchPlans(Authorization, req)
+	if errors.Is(err, zhjwService.ErrCookieExpired) {
+		response.CookieExpired(c)
+		return
+	} else if err != nil {
+		response.FailWithCode(c, 1, "查询培养方案失败: "+err.Error())
+		return
+	}
+	response.Success(c, data)
+
+}
+
+// GetPlanDetail 获取指定培养方案的详情
+func GetPlanDetail(c *gin.Context) {
+
+	// 获取参数，能放行到这里，说明已经通过鉴权中间件检查
+	Authorization := request.GetCurrentUserAuthorization(c)
+
+	// 绑定查询参数到结构体
+	var req model.PlanDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "查询参数错误，请检查后重试")
+		return
+	}
+
+	// 调用业务逻辑 (Service 层)
+	data, err := zhjwService.FetchPlanByID(Authorization, req.PlanID)
+	if errors.Is(err, zhjwService.ErrCookieExpired) {
+		response.CookieExpired(c)
+		return
+	} else if errors.Is(err, zhjwService.ErrResourceNotFound) {
+		response.ResourceNotFound(c)
+		return
+	} else if err != nil {
+		response.FailWithCode(c, 1, "获取培养方案详情失败: "+err.Error())
+		return
+	}
+	response.Success(c, data)
+
+}
+
+// ComparePlan 对比当前修读记录与目标培养方案
+func ComparePlan(c *gin.Context) {
+
+	// 获取参数，能放行到这里，说明已经通过鉴权中间件检查
+	Authorization := request.GetCurrentUserAuthorization(c)
+
+	// 绑定查询参数到结构体
+	var req model.PlanDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "查询参数错误，请检查后重试")
+		return
+	}
+
+	// 调用业务逻辑 (Service 层)
+	data, err := zhjwService.ComparePlan(Authorization, req.PlanID)
+	if errors.Is(err, zhjwService.ErrCookieExpired) {
+		response.CookieExpired(c)
+		return
+	} else if errors.Is(err, zhjwService.ErrResourceNotFound) {
+		response.ResourceNotFound(c)
+		return
+	} else if errors.Is(err, zhjwService.ErrEvaluationPending) {
+		evaluationPending(c, Authorization)
+		return
+	} else if err != nil {
+		response.FailWithCode(c, 1, "培养方案对比失败: "+err.Error())
+		return
+	}
+	response.Success(c, data)
+
+}
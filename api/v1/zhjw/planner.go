@@ -0,0 +1,46 @@
+package zhjw
+
+import (
+	"errors"
+
+	"github.com/W1ndys/easy-qfnu-api-go/common/request"
+	"github.com/W1ndys/easy-qfnu-api-go/common/response"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+	zhjwService "github.com/W1ndys/easy-qfnu-api-go/services/zhjw"
+	"github.com/gin-gonic/gin"
+)
+
+// PlanTimetable 根据候选课程和已有课表生成无冲突的选课方案
+func PlanTimetable(c *gin.Context) {
+
+	// 获取参数，能放行到这里，说明已经通过鉴权中间件检查
+	Authorization := request.GetCurrentUserAuthorization(c)
+
+	// 绑定请求体到结构体
+	var req model.PlannerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "请求参数错误，请检查后重试")
+		return
+	}
+	if len(req.Candidates) == 0 {
+		response.FailWithCode(c, response.CodeInvalidParam, "请至少提供一门候选课程")
+		return
+	}
+
+	// 调用业务逻辑 (Service 层)
+	data, err := zhjwService.PlanTimetable(Authorization, req)
+	// 处理业务结果
+	// 如果有错误，返回错误信息
+	if errors.Is(err, zhjwService.ErrCookieExpired) {
+		response.CookieExpired(c)
+		return
+	} else if errors.Is(err, zhjwService.ErrPlannerTooLarge) {
+		response.FailWithCode(c, response.CodeInvalidParam, "候选课程过多，最多 15 门课程，每门课最多 30 个教学班")
+		return
+	} else if err != nil {
+		response.FailWithCode(c, 1, "生成选课方案失败: "+err.Error())
+		return
+	}
+	response.Success(c, data)
+
+}
@@ -24,8 +24,8 @@ func GetSelectionResults(c *gin.Context) {
 	}
 
 	// 调用业务逻辑 (Service 层)
-	// 这里的 FetchSelectionResults 首字母是大写，所以能被跨包调用
-	data, err := zhjwService.FetchSelectionResults(Authorization, req.Term)
+	// 这里的 FetchSelectionSummary 首字母是大写，所以能被跨包调用
+	data, err := zhjwService.FetchSelectionSummary(Authorization, req.Term)
 	// 处理业务结果
 	// 如果有错误，返回错误信息
 	if errors.Is(err, zhjwService.ErrCookieExpired) {
@@ -0,0 +1,57 @@
+package zhjw
+
+import (
+	"errors"
+
+	"github.com/W1ndys/easy-qfnu-api-go/common/request"
+	"github.com/W1ndys/easy-qfnu-api-go/common/response"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+	zhjwService "github.com/W1ndys/easy-qfnu-api-go/services/zhjw"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTeacherTimetable 按教师姓名查询课表
+func GetTeacherTimetable(c *gin.Context) {
+	getTimetable(c, zhjwService.TimetableTeacher)
+}
+
+// GetClassTimetable 按行政班级查询课表
+func GetClassTimetable(c *gin.Context) {
+	getTimetable(c, zhjwService.TimetableClass)
+}
+
+// GetClassroomTimetable 按教室查询课表
+func GetClassroomTimetable(c *gin.Context) {
+	getTimetable(c, zhjwService.TimetableClassroom)
+}
+
+// getTimetable 公共课表查询的通用处理逻辑
+func getTimetable(c *gin.Context, kind zhjwService.TimetableKind) {
+
+	// 获取参数，能放行到这里，说明已经通过鉴权中间件检查
+	Authorization := request.GetCurrentUserAuthorization(c)
+
+	// 绑定查询参数到结构体
+	var req model.TimetableSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailWithCode(c, response.CodeInvalidParam, "查询参数错误，请检查后重试")
+		return
+	}
+
+	// 调用业务逻辑 (Service 层)
+	data, err := zhjwService.FetchTimetable(Authorization, kind, req)
+	// 处理业务结果
+	// 如果有错误，返回错误信息
+	if errors.Is(err, zhjwService.ErrCookieExpired) {
+		response.CookieExpired(c)
+		return
+	} else if errors.Is(err, zhjwService.ErrResourceNotFound) {
+		response.ResourceNotFound(c)
+		return
+	} else if err != nil {
+		response.FailWithCode(c, 1, "查询课表失败: "+err.Error())
+		return
+	}
+	response.Success(c, data)
+
+}
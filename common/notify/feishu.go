@@ -200,6 +200,29 @@ func NotifyNewRecommendation(courseName, teacher, recommender, reason string) {
 	defaultNotifier.SendAsync("新选课推荐", content, "blue")
 }
 
+// NotifyFlaggedRecommendation 被自动标记的选课推荐提交通知
+func NotifyFlaggedRecommendation(courseName, teacher, recommender, reason, flagReason string) {
+	if defaultNotifier == nil {
+		return
+	}
+
+	content := fmt.Sprintf(`**⚠️ 收到被标记的选课推荐，请重点审核**
+
+- **标记原因**: %s
+- **课程名称**: %s
+- **授课教师**: %s
+- **推荐人**: %s
+- **推荐理由**: %s`,
+		flagReason,
+		courseName,
+		teacher,
+		recommender,
+		reason,
+	)
+
+	defaultNotifier.SendAsync("待审核选课推荐", content, "orange")
+}
+
 // NotifyError 系统错误通知
 func NotifyError(errType, errMsg, stack string) {
 	if defaultNotifier == nil {
@@ -1,6 +1,13 @@
 package request
 
-import "github.com/gin-gonic/gin"
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// clientIDPattern 前端生成的客户端标识，只接受字母、数字和连字符
+var clientIDPattern = regexp.MustCompile(`^[A-Za-z0-9-]{8,64}$`)
 
 // GetCurrentUserAuthorization 从上下文中安全获取 Authorization
 func GetCurrentUserAuthorization(c *gin.Context) string {
@@ -8,3 +15,18 @@ func GetCurrentUserAuthorization(c *gin.Context) string {
 	// 因为中间件已经保证了 Authorization 存在，这里可以用 GetString
 	return c.GetString("Authorization")
 }
+
+// GetCurrentAdmin 从上下文中获取当前登录的管理员账号，由 AdminAuthRequired 中间件写入
+func GetCurrentAdmin(c *gin.Context) string {
+	return c.GetString("AdminUser")
+}
+
+// GetClientFingerprint 获取前端保存在 localStorage 中的客户端标识 (X-Client-ID)，用于匿名接口的限流和去重
+// 该标识由客户端自行生成，可以随意更换，只能作为 IP 之外的辅助维度；缺失或格式不对时返回空字符串
+// 不再退化为 User-Agent 摘要：同一型号的手机和浏览器会落入同一个桶，误伤大量正常用户
+func GetClientFingerprint(c *gin.Context) string {
+	if id := c.GetHeader("X-Client-ID"); clientIDPattern.MatchString(id) {
+		return "id:" + id
+	}
+	return ""
+}
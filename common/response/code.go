@@ -2,22 +2,26 @@ package response
 
 // 业务状态码常量
 const (
-	CodeSuccess          = 200  // 成功
-	CodeServerBusy       = 1    // 系统繁忙/通用错误
-	CodeInvalidParam     = 1001 // 参数错误
-	CodeAuthExpired      = 401  // 缺少 Authorization 或 Authorization 过期
-	CodeResourceNotFound = 404  // 未查询到数据
-	CodeTargetError      = 502  // 教务系统挂了
+	CodeSuccess           = 200  // 成功
+	CodeServerBusy        = 1    // 系统繁忙/通用错误
+	CodeInvalidParam      = 1001 // 参数错误
+	CodeAuthExpired       = 401  // 缺少 Authorization 或 Authorization 过期
+	CodeResourceNotFound  = 404  // 未查询到数据
+	CodeTargetError       = 502  // 教务系统挂了
+	CodeEvaluationPending = 1002 // 有未完成的评教，教务系统暂不允许查询成绩
+	CodeTooManyRequests   = 429  // 请求过于频繁
 )
 
 // MsgFlags 状态码对应的默认提示信息
 var MsgFlags = map[int]string{
-	CodeSuccess:          "success",
-	CodeServerBusy:       "系统繁忙，请稍后再试",
-	CodeInvalidParam:     "请求参数错误",
-	CodeAuthExpired:      "缺少 Authorization 字段 或 Authorization 过期，请重新获取相关系统的Cookie，获取方法参考 https://mp.weixin.qq.com/s/zFK9c4ecpGdRwXSKzaVFnw",
-	CodeResourceNotFound: "未查询到数据，请调整查询条件后重试",
-	CodeTargetError:      "目标系统无响应",
+	CodeSuccess:           "success",
+	CodeServerBusy:        "系统繁忙，请稍后再试",
+	CodeInvalidParam:      "请求参数错误",
+	CodeAuthExpired:       "缺少 Authorization 字段 或 Authorization 过期，请重新获取相关系统的Cookie，获取方法参考 https://mp.weixin.qq.com/s/zFK9c4ecpGdRwXSKzaVFnw",
+	CodeResourceNotFound:  "未查询到数据，请调整查询条件后重试",
+	CodeTargetError:       "目标系统无响应",
+	CodeEvaluationPending: "请先在教务系统完成评教后再查询成绩",
+	CodeTooManyRequests:   "操作过于频繁，请稍后再试",
 }
 
 // GetMsg 获取状态码对应的消息
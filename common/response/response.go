@@ -50,3 +50,8 @@ func FailWithCode(c *gin.Context, code int, msg string) {
 	// 这里必须写 Result[any]
 	Result[any](c, http.StatusOK, code, msg, nil)
 }
+
+// FailWithData 自定义错误码响应，并附带数据 (如需要用户处理的事项列表)
+func FailWithData[T any](c *gin.Context, code int, msg string, data T) {
+	Result(c, http.StatusOK, code, msg, data)
+}
@@ -10,6 +10,7 @@ require (
 	github.com/go-resty/resty/v2 v2.17.1
 	github.com/joho/godotenv v1.5.1
 	github.com/lmittmann/tint v1.1.2
+	github.com/mozillazg/go-pinyin v0.21.0
 	github.com/samber/slog-multi v1.7.0
 	golang.org/x/crypto v0.47.0
 	gopkg.in/natefinch/lumberjack.v2 v2.2.1
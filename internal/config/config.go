@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/W1ndys/easy-qfnu-api-go/internal/crypto"
@@ -12,6 +14,30 @@ const (
 	KeySiteAccessPassword = "site_access_password"
 	KeyAdminPassword      = "admin_password"
 	KeyTokenExpireHours   = "token_expire_hours"
+	KeySelectionMinCredit = "selection_min_credits"
+	KeySelectionMaxCredit = "selection_max_credits"
+
+	KeyRecommendationRateLimit       = "recommendation_rate_limit"
+	KeyRecommendationSensitiveWords  = "recommendation_sensitive_words"
+	KeyRecommendationSensitiveAction = "recommendation_sensitive_action"
+)
+
+// AdminAccount 管理员账号，写入管理员登录 Token 并作为审核人记录
+// 后台目前只有一个管理员账号且登录只需密码，所有审核记录的审核人都是该账号，支持多管理员后再按账号区分
+const AdminAccount = "admin"
+
+// 每学期选课学分上下限的默认值，0 表示不限制
+const (
+	DefaultSelectionMinCredits = 0
+	DefaultSelectionMaxCredits = 30
+)
+
+// 课程推荐投稿的默认限制
+const (
+	DefaultRecommendationRateLimit = 5 // 同一 IP 或同一客户端每小时最多提交次数，0 表示不限制
+
+	SensitiveActionFlag   = "flag"   // 命中敏感词时标记后进入审核队列
+	SensitiveActionReject = "reject" // 命中敏感词时直接拒绝
 )
 
 // Get 获取配置值
@@ -75,6 +101,53 @@ func GetTokenExpireHours() int {
 	return hours
 }
 
+// GetSelectionCreditLimits 获取每学期选课学分的下限和上限
+func GetSelectionCreditLimits() (minCredits float64, maxCredits float64) {
+	return getFloat(KeySelectionMinCredit, DefaultSelectionMinCredits),
+		getFloat(KeySelectionMaxCredit, DefaultSelectionMaxCredits)
+}
+
+// GetRecommendationRateLimit 获取同一 IP 或同一客户端每小时最多提交课程推荐的次数
+func GetRecommendationRateLimit() int {
+	value, err := strconv.Atoi(Get(KeyRecommendationRateLimit))
+	if err != nil || value < 0 {
+		return DefaultRecommendationRateLimit
+	}
+	return value
+}
+
+// GetRecommendationSensitiveWords 获取课程推荐敏感词列表，配置中按换行或逗号分隔
+func GetRecommendationSensitiveWords() []string {
+	fields := strings.FieldsFunc(Get(KeyRecommendationSensitiveWords), func(r rune) bool {
+		return r == '\n' || r == ',' || r == '，'
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+// GetRecommendationSensitiveAction 获取命中敏感词时的处理方式，默认标记后进入审核队列
+func GetRecommendationSensitiveAction() string {
+	if Get(KeyRecommendationSensitiveAction) == SensitiveActionReject {
+		return SensitiveActionReject
+	}
+	return SensitiveActionFlag
+}
+
+// getFloat 读取数值型配置，未设置或格式错误时返回默认值
+func getFloat(key string, def float64) float64 {
+	value, err := strconv.ParseFloat(Get(key), 64)
+	if err != nil || value < 0 {
+		return def
+	}
+	return value
+}
+
 // VerifySitePassword 验证访问密码
 func VerifySitePassword(password string) bool {
 	hash := Get(KeySiteAccessPassword)
@@ -38,15 +38,22 @@ func CheckPassword(password, hash string) bool {
 
 // TokenPayload Token 载荷结构
 type TokenPayload struct {
-	Type string `json:"type"`
-	Exp  int64  `json:"exp"`
+	Type    string `json:"type"`
+	Subject string `json:"sub,omitempty"` // 持有者账号，访问 Token 不区分用户时为空
+	Exp     int64  `json:"exp"`
 }
 
 // GenerateToken 生成 Token
 func GenerateToken(tokenType string, expireHours int) string {
+	return GenerateTokenFor(tokenType, "", expireHours)
+}
+
+// GenerateTokenFor 生成带持有者账号的 Token
+func GenerateTokenFor(tokenType string, subject string, expireHours int) string {
 	payload := TokenPayload{
-		Type: tokenType,
-		Exp:  time.Now().Add(time.Hour * time.Duration(expireHours)).Unix(),
+		Type:    tokenType,
+		Subject: subject,
+		Exp:     time.Now().Add(time.Hour * time.Duration(expireHours)).Unix(),
 	}
 
 	payloadJSON, _ := json.Marshal(payload)
@@ -61,9 +68,15 @@ func GenerateToken(tokenType string, expireHours int) string {
 
 // ValidateToken 验证 Token
 func ValidateToken(token string, expectedType string) bool {
+	_, ok := ParseToken(token, expectedType)
+	return ok
+}
+
+// ParseToken 验证 Token 并返回载荷，签名、类型或过期时间不对时 ok 为 false
+func ParseToken(token string, expectedType string) (*TokenPayload, bool) {
 	parts := strings.Split(token, ".")
 	if len(parts) != 2 {
-		return false
+		return nil, false
 	}
 
 	// 验证签名
@@ -71,29 +84,29 @@ func ValidateToken(token string, expectedType string) bool {
 	h.Write([]byte(parts[0]))
 	expectedSig := base64.URLEncoding.EncodeToString(h.Sum(nil))
 	if parts[1] != expectedSig {
-		return false
+		return nil, false
 	}
 
 	// 解析 Payload
 	payloadJSON, err := base64.URLEncoding.DecodeString(parts[0])
 	if err != nil {
-		return false
+		return nil, false
 	}
 
 	var payload TokenPayload
 	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
-		return false
+		return nil, false
 	}
 
 	// 验证类型
 	if payload.Type != expectedType {
-		return false
+		return nil, false
 	}
 
 	// 验证过期时间
 	if time.Now().Unix() > payload.Exp {
-		return false
+		return nil, false
 	}
 
-	return true
+	return &payload, true
 }
@@ -189,6 +189,42 @@ func initAppTables() {
 			updated_at INTEGER NOT NULL
 		)
 	`)
+
+	// 校历学期表
+	appDB.Exec(`
+		CREATE TABLE IF NOT EXISTS calendar_terms (
+			term TEXT PRIMARY KEY,
+			start_date TEXT NOT NULL,
+			total_weeks INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)
+	`)
+
+	// 校历特殊日期表 (放假 / 调休补课)
+	appDB.Exec(`
+		CREATE TABLE IF NOT EXISTS calendar_special_days (
+			date TEXT PRIMARY KEY,
+			type TEXT NOT NULL,
+			name TEXT DEFAULT '',
+			follow_date TEXT DEFAULT '',
+			updated_at INTEGER NOT NULL
+		)
+	`)
+
+	// 教务处通知公告缓存表
+	appDB.Exec(`
+		CREATE TABLE IF NOT EXISTS news (
+			id TEXT PRIMARY KEY,
+			title TEXT NOT NULL,
+			url TEXT NOT NULL,
+			published_at TEXT DEFAULT '',
+			content TEXT DEFAULT '',
+			attachments TEXT DEFAULT '[]',
+			fetched_at INTEGER NOT NULL,
+			detail_fetched_at INTEGER DEFAULT 0
+		)
+	`)
+	appDB.Exec(`CREATE INDEX IF NOT EXISTS idx_news_published ON news(published_at)`)
 }
 
 // initCourseRecTables 初始化课程推荐数据库表
@@ -216,16 +252,123 @@ func initCourseRecTables() {
 	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_course_rec_visible ON course_recommendations(is_visible)`)
 
 	// 简单的迁移逻辑：检查新字段是否存在，不存在则添加
-	var count int
-	// 检查 campus
-	err := courseRecDB.QueryRow("SELECT COUNT(*) FROM pragma_table_info('course_recommendations') WHERE name='campus'").Scan(&count)
-	if err == nil && count == 0 {
-		courseRecDB.Exec("ALTER TABLE course_recommendations ADD COLUMN campus TEXT DEFAULT ''")
-	}
+	addColumnIfMissing(courseRecDB, "course_recommendations", "campus", "TEXT DEFAULT ''")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "recommendation_year", "TEXT DEFAULT ''")
+
+	// 多维度评分，0 表示未评分
+	addColumnIfMissing(courseRecDB, "course_recommendations", "rating_difficulty", "INTEGER DEFAULT 0")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "rating_workload", "INTEGER DEFAULT 0")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "rating_grading", "INTEGER DEFAULT 0")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "rating_attendance", "INTEGER DEFAULT 0")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "rating_overall", "INTEGER DEFAULT 0")
+
+	// 关联的规范课程 / 教师，0 表示尚未关联
+	addColumnIfMissing(courseRecDB, "course_recommendations", "course_id", "INTEGER DEFAULT 0")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "teacher_id", "INTEGER DEFAULT 0")
+	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_course_rec_course_id ON course_recommendations(course_id)`)
+	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_course_rec_teacher_id ON course_recommendations(teacher_id)`)
+
+	// 命中敏感词的投稿会被标记，供管理员审核时参考
+	addColumnIfMissing(courseRecDB, "course_recommendations", "flagged", "INTEGER DEFAULT 0")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "flag_reason", "TEXT DEFAULT ''")
+
+	// 认为有用的人数，由 recommendation_votes 汇总而来，便于排序
+	addColumnIfMissing(courseRecDB, "course_recommendations", "helpful_count", "INTEGER DEFAULT 0")
+
+	// 有用投票表，每个客户端对每条推荐只计一次
+	courseRecDB.Exec(`
+		CREATE TABLE IF NOT EXISTS recommendation_votes (
+			recommendation_id INTEGER NOT NULL,
+			client_key TEXT NOT NULL,
+			created_at INTEGER NOT NULL,
+			PRIMARY KEY (recommendation_id, client_key)
+		)
+	`)
 
-	// 检查 recommendation_year
-	err = courseRecDB.QueryRow("SELECT COUNT(*) FROM pragma_table_info('course_recommendations') WHERE name='recommendation_year'").Scan(&count)
+	// 举报表
+	courseRecDB.Exec(`
+		CREATE TABLE IF NOT EXISTS recommendation_reports (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			recommendation_id INTEGER NOT NULL,
+			client_key TEXT NOT NULL,
+			reason TEXT NOT NULL,
+			status TEXT DEFAULT 'open',
+			resolution TEXT DEFAULT '',
+			created_at INTEGER NOT NULL,
+			resolved_at INTEGER DEFAULT 0
+		)
+	`)
+	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_reports_status ON recommendation_reports(status)`)
+	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_reports_recommendation ON recommendation_reports(recommendation_id)`)
+
+	// 投票和举报按 IP 加客户端标识去重，同一 IP 对同一推荐的次数另有上限
+	addColumnIfMissing(courseRecDB, "recommendation_votes", "ip", "TEXT DEFAULT ''")
+	addColumnIfMissing(courseRecDB, "recommendation_reports", "ip", "TEXT DEFAULT ''")
+	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_votes_ip ON recommendation_votes(recommendation_id, ip)`)
+	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_reports_ip ON recommendation_reports(recommendation_id, ip)`)
+
+	// 审核状态：pending 待审核，approved 已通过，rejected 已拒绝，hidden 已隐藏
+	// is_visible 保留并与 status 同步 (仅 approved 时为 1)，历史数据中已公开的记录迁移为 approved
+	addColumnIfMissing(courseRecDB, "course_recommendations", "status", "TEXT DEFAULT 'pending'")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "reviewer", "TEXT DEFAULT ''")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "reviewed_at", "INTEGER DEFAULT 0")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "reject_reason", "TEXT DEFAULT ''")
+	addColumnIfMissing(courseRecDB, "course_recommendations", "status_token", "TEXT DEFAULT ''")
+	courseRecDB.Exec(`UPDATE course_recommendations SET status = 'approved' WHERE is_visible = 1 AND status = 'pending'`)
+	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_course_rec_status ON course_recommendations(status)`)
+	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_course_rec_status_token ON course_recommendations(status_token)`)
+
+	// 规范课程表及其别名
+	courseRecDB.Exec(`
+		CREATE TABLE IF NOT EXISTS course_entities (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			created_at INTEGER NOT NULL
+		)
+	`)
+	courseRecDB.Exec(`
+		CREATE TABLE IF NOT EXISTS course_aliases (
+			alias_key TEXT PRIMARY KEY,
+			alias TEXT NOT NULL,
+			entity_id INTEGER NOT NULL
+		)
+	`)
+	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_course_aliases_entity ON course_aliases(entity_id)`)
+
+	// 规范教师表及其别名
+	courseRecDB.Exec(`
+		CREATE TABLE IF NOT EXISTS teacher_entities (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			created_at INTEGER NOT NULL
+		)
+	`)
+	courseRecDB.Exec(`
+		CREATE TABLE IF NOT EXISTS teacher_aliases (
+			alias_key TEXT PRIMARY KEY,
+			alias TEXT NOT NULL,
+			entity_id INTEGER NOT NULL
+		)
+	`)
+	courseRecDB.Exec(`CREATE INDEX IF NOT EXISTS idx_teacher_aliases_entity ON teacher_aliases(entity_id)`)
+
+	// 课程推荐全文索引，rowid 与 course_recommendations.id 一致
+	// 中文按单字分词后写入，由 services/course_recommendation 维护
+	courseRecDB.Exec(`
+		CREATE VIRTUAL TABLE IF NOT EXISTS course_recommendations_fts USING fts5(
+			course_name,
+			teacher_name,
+			recommendation_reason,
+			teacher_pinyin
+		)
+	`)
+}
+
+// addColumnIfMissing 检查字段是否存在，不存在则添加
+func addColumnIfMissing(db *sql.DB, table, column, definition string) {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('"+table+"') WHERE name = ?", column).Scan(&count)
 	if err == nil && count == 0 {
-		courseRecDB.Exec("ALTER TABLE course_recommendations ADD COLUMN recommendation_year TEXT DEFAULT ''")
+		db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition)
 	}
 }
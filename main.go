@@ -11,6 +11,8 @@ import (
 	"github.com/W1ndys/easy-qfnu-api-go/common/stats"
 	"github.com/W1ndys/easy-qfnu-api-go/internal/config"
 	"github.com/W1ndys/easy-qfnu-api-go/router"
+	courseRecommendation "github.com/W1ndys/easy-qfnu-api-go/services/course_recommendation"
+	"github.com/W1ndys/easy-qfnu-api-go/services/news"
 	"github.com/fatih/color"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -54,6 +56,13 @@ func main() {
 	// 初始化飞书通知
 	notify.InitFeishu()
 
+	// 为历史课程推荐关联规范课程和教师，并重建全文索引
+	courseRecommendation.LinkUnlinked()
+	courseRecommendation.RebuildSearchIndex()
+
+	// 启动通知公告定时刷新
+	news.StartRefresher()
+
 	// 初始化路由 (注入 webFS)
 	r := router.InitRouter(webFS)
 
@@ -15,7 +15,7 @@ func Cors() gin.HandlerFunc {
 		if origin != "" {
 			// 允许所有来源，生产环境建议换成你的前端域名
 			c.Header("Access-Control-Allow-Origin", "easy-qfnu.top")
-			c.Header("Access-Control-Allow-Headers", "Content-Type, AccessToken, X-CSRF-Token, Authorization, Token, Authorization")
+			c.Header("Access-Control-Allow-Headers", "Content-Type, AccessToken, X-CSRF-Token, Authorization, Token, Authorization, X-Client-ID")
 			c.Header("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE, UPDATE")
 			c.Header("Access-Control-Expose-Headers", "Content-Length, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Content-Type")
 			c.Header("Access-Control-Allow-Credentials", "true")
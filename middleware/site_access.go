@@ -60,13 +60,21 @@ func AdminAuthRequired() gin.HandlerFunc {
 			return
 		}
 
-		if !crypto.ValidateToken(token, "admin") {
+		payload, ok := crypto.ParseToken(token, "admin")
+		if !ok {
 			c.SetCookie(AdminTokenCookie, "", -1, "/", "", false, true)
 			c.Redirect(http.StatusFound, "/admin/login")
 			c.Abort()
 			return
 		}
 
+		// 将 Token 中的管理员账号放入上下文，供审核等操作记录操作人
+		account := payload.Subject
+		if account == "" {
+			// 升级前签发的 Token 不带账号
+			account = config.AdminAccount
+		}
+		c.Set("AdminUser", account)
 		c.Next()
 	}
 }
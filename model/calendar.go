@@ -0,0 +1,65 @@
+package model
+
+// 校历特殊日期类型
+const (
+	CalendarDayHoliday = "holiday" // 放假
+	CalendarDayMakeup  = "makeup"  // 调休补课
+)
+
+// CalendarTerm 校历中的一个学期
+type CalendarTerm struct {
+	Term       string `json:"term"`        // 学年学期，如 2025-2026-1
+	StartDate  string `json:"start_date"`  // 第 1 周周一，格式 2006-01-02
+	TotalWeeks int    `json:"total_weeks"` // 教学周数
+	UpdatedAt  int64  `json:"updated_at"`  // 更新时间
+}
+
+// CalendarTermRequest 新增 / 更新学期请求 (管理员)
+type CalendarTermRequest struct {
+	Term       string `json:"term" binding:"required"`
+	StartDate  string `json:"start_date" binding:"required"`
+	TotalWeeks int    `json:"total_weeks" binding:"required"`
+}
+
+// CalendarSpecialDay 校历中的放假或调休补课日期
+type CalendarSpecialDay struct {
+	Date       string `json:"date"`        // 日期，格式 2006-01-02
+	Type       string `json:"type"`        // holiday / makeup
+	Name       string `json:"name"`        // 说明，如 "国庆节"
+	FollowDate string `json:"follow_date"` // 调休补课时按哪一天的课表上课，放假时为空
+	UpdatedAt  int64  `json:"updated_at"`  // 更新时间
+}
+
+// CalendarSpecialDayRequest 新增 / 更新特殊日期请求 (管理员)
+type CalendarSpecialDayRequest struct {
+	Date       string `json:"date" binding:"required"`
+	Type       string `json:"type" binding:"required"`
+	Name       string `json:"name"`
+	FollowDate string `json:"follow_date"`
+}
+
+// CalendarDeleteRequest 删除学期或特殊日期请求 (管理员)
+type CalendarDeleteRequest struct {
+	Key string `json:"key" binding:"required"` // 学期或日期
+}
+
+// CalendarRequest 校历查询参数
+type CalendarRequest struct {
+	Date string `form:"date"` // 日期 (e.g., 2026-01-01)，为空时为今天
+}
+
+// CalendarDay 某一天在校历中的情况
+type CalendarDay struct {
+	Date       string `json:"date"`                  // 查询日期
+	Term       string `json:"term"`                  // 所属学年学期，不在任何学期内时为空
+	InTerm     bool   `json:"in_term"`               // 是否在教学周历内
+	Week       int    `json:"week"`                  // 教学周，不在教学周历内时为 0
+	DayOfWeek  int    `json:"day_of_week"`           // 星期几 (1-7)
+	HasClass   bool   `json:"has_class"`             // 当天是否上课
+	DayType    string `json:"day_type,omitempty"`    // holiday / makeup，普通日期为空
+	DayName    string `json:"day_name,omitempty"`    // 放假或调休说明
+	FollowDate string `json:"follow_date,omitempty"` // 调休补课时按哪一天的课表上课
+	// 实际执行课表的教学周和星期，调休补课时与 FollowDate 一致，否则与 Week / DayOfWeek 相同
+	ScheduleWeek      int `json:"schedule_week"`
+	ScheduleDayOfWeek int `json:"schedule_day_of_week"`
+}
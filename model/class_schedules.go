@@ -2,6 +2,10 @@ package model
 
 // {
 //   "currentWeekRaw": "第18周/20周",
+//   "currentWeek": 18,
+//   "totalWeeks": 20,
+//   "inTerm": true,
+//   "semesterStart": "2025-09-01",
 //   "courses": [
 //     {
 //       "index": 1,
@@ -22,27 +26,34 @@ package model
 
 // ClassScheduleResponse 课程表响应结构
 type ClassScheduleResponse struct {
-	CurrentWeekRaw string           `json:"currentWeekRaw"` // 当前周次原始字符串
-	Courses        []ClassSchedules `json:"courses"`        // 课程列表
+	CurrentWeekRaw string           `json:"currentWeekRaw"`     // 当前周次原始字符串
+	CurrentWeek    int              `json:"currentWeek"`        // 当前教学周，不在教学周历内时为 0
+	TotalWeeks     int              `json:"totalWeeks"`         // 本学期总周数
+	InTerm         bool             `json:"inTerm"`             // 查询日期是否在教学周历内
+	SemesterStart  string           `json:"semesterStart"`      // 推算出的学期开始日期 (第 1 周周一)，无法推算时为空
+	Courses        []ClassSchedules `json:"courses"`            // 课程列表
+	Calendar       *CalendarDay     `json:"calendar,omitempty"` // 校历信息 (放假、调休补课)，校历未覆盖该日期时为空
 }
 
 // ClassSchedules 课程表信息
 type ClassSchedules struct {
-	Index         int            `json:"index"`         // 课程索引
-	Name          string         `json:"name"`          // 课程名称
-	Credit        string         `json:"credit"`        // 学分
-	Category      string         `json:"category"`      // 课程类别
-	Location      string         `json:"location"`      // 上课地点
-	Classes       string         `json:"classes"`       // 上课班级
-	RawTimeString string         `json:"rawTimeString"` // 原始时间字符串
-	TimeParsed    ClassTimeParse `json:"timeParsed"`    // 解析后的时间信息
+	Index         int            `json:"index"`             // 课程索引
+	Name          string         `json:"name"`              // 课程名称
+	Credit        string         `json:"credit"`            // 学分
+	Category      string         `json:"category"`          // 课程类别
+	Location      string         `json:"location"`          // 上课地点
+	Classes       string         `json:"classes"`           // 上课班级
+	Teacher       string         `json:"teacher,omitempty"` // 授课教师，仅公共课表查询返回
+	RawTimeString string         `json:"rawTimeString"`     // 原始时间字符串
+	TimeParsed    ClassTimeParse `json:"timeParsed"`        // 解析后的时间信息
 }
 
 // ClassTimeParse 课程时间解析信息
 type ClassTimeParse struct {
-	Week        int   `json:"week"`        // 周次
-	DayOfWeek   int   `json:"dayOfWeek"`   // 星期几 (1-7)
-	PeriodArray []int `json:"periodArray"` // 节次数组
+	Week        int   `json:"week"`            // 周次
+	DayOfWeek   int   `json:"dayOfWeek"`       // 星期几 (1-7)
+	PeriodArray []int `json:"periodArray"`     // 节次数组
+	Weeks       []int `json:"weeks,omitempty"` // 上课周次列表，仅整学期课表返回
 }
 
 // ClassSchedulesRequest 课程表请求结构
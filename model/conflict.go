@@ -0,0 +1,42 @@
+package model
+
+// 冲突类型
+const (
+	ConflictClassClass = "class_class" // 两门课程上课时间重叠
+	ConflictClassExam  = "class_exam"  // 考试与上课时间重叠
+	ConflictExamExam   = "exam_exam"   // 两场考试时间重叠
+)
+
+// ConflictCheckRequest 冲突检测请求参数
+type ConflictCheckRequest struct {
+	Term string `form:"term" binding:"required"` // 学年学期 (e.g., 2025-2026-1)
+}
+
+// ConflictItem 参与冲突的一项 (课程或考试)
+type ConflictItem struct {
+	Source     string `json:"source"`      // 来源：class 课表 / exam 考试安排
+	CourseName string `json:"course_name"` // 课程名称
+	CourseId   string `json:"course_id"`   // 课程编号，来自选课结果或考试安排，可能为空
+	Teacher    string `json:"teacher"`     // 授课教师
+	Location   string `json:"location"`    // 上课地点 / 考场
+	TimeText   string `json:"time_text"`   // 原始时间字符串
+	Selected   bool   `json:"selected"`    // 是否出现在本学期选课结果中
+}
+
+// ScheduleConflict 一处时间冲突
+type ScheduleConflict struct {
+	Type      string         `json:"type"`        // 冲突类型
+	Items     []ConflictItem `json:"items"`       // 冲突双方
+	DayOfWeek int            `json:"day_of_week"` // 星期几 (1-7)
+	Periods   []int          `json:"periods"`     // 重叠的节次，考试之间的冲突为空
+	Weeks     []int          `json:"weeks"`       // 受影响的教学周
+	Dates     []string       `json:"dates"`       // 受影响的日期，无法确定学期开始日期时为空
+}
+
+// ConflictCheckResponse 冲突检测响应结构
+type ConflictCheckResponse struct {
+	Term          string             `json:"term"`           // 学年学期
+	SemesterStart string             `json:"semester_start"` // 学期开始日期，无法确定时为空
+	Conflicts     []ScheduleConflict `json:"conflicts"`      // 冲突列表
+	Warnings      []string           `json:"warnings"`       // 部分检查无法完成时的说明
+}
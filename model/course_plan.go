@@ -1,15 +1,41 @@
 package model
 
+// 课程完成情况
+const (
+	CourseStatusCompleted  = "completed"   // 已修
+	CourseStatusInProgress = "in_progress" // 在修
+	CourseStatusNotStarted = "not_started" // 未修
+	CourseStatusExempted   = "exempted"    // 免修
+	CourseStatusUnknown    = "unknown"     // 无法识别
+)
+
+// CourseHours 学时分类
+type CourseHours struct {
+	Lecture         float64 `json:"lecture"`         // 讲课学时
+	Practice        float64 `json:"practice"`        // 实践学时
+	Seminar         float64 `json:"seminar"`         // 讲座学时
+	Experiment      float64 `json:"experiment"`      // 实验学时
+	Design          float64 `json:"design"`          // 设计学时
+	Computer        float64 `json:"computer"`        // 其中上机学时
+	Discussion      float64 `json:"discussion"`      // 讨论辅导学时
+	Extracurricular float64 `json:"extracurricular"` // 课外学时
+	Online          float64 `json:"online"`          // 网络学时
+	Total           float64 `json:"total"`           // 总学时
+}
+
 // CoursePlanInfo 课程详细信息
 type CoursePlanInfo struct {
-	CourseName string  `json:"course_name"` // 课程名称
-	CourseCode string  `json:"course_code"` // 课程编号
-	Status     string  `json:"status"`      // 完成情况 (e.g., 已修(优))
-	CourseProp string  `json:"course_prop"` // 课程性质 (e.g., 公共必修课)
-	CourseAttr string  `json:"course_attr"` // 课程属性 (e.g., 必修)
-	Credits    float64 `json:"credits"`     // 学分
-	Hours      string  `json:"hours"`       // 总学时
-	Term       string  `json:"term"`        // 开设学期
+	CourseName    string      `json:"course_name"`    // 课程名称
+	CourseCode    string      `json:"course_code"`    // 课程编号
+	Status        string      `json:"status"`         // 完成情况 (e.g., 已修(优))
+	StatusCode    string      `json:"status_code"`    // 完成情况枚举：completed / in_progress / not_started / exempted / unknown
+	StatusGrade   string      `json:"status_grade"`   // 完成情况中括号内的成绩 (e.g., 优)，没有时为空
+	CourseProp    string      `json:"course_prop"`    // 课程性质 (e.g., 公共必修课)
+	CourseAttr    string      `json:"course_attr"`    // 课程属性 (e.g., 必修)
+	Credits       float64     `json:"credits"`        // 学分
+	Hours         string      `json:"hours"`          // 总学时
+	HourBreakdown CourseHours `json:"hour_breakdown"` // 学时分类
+	Term          string      `json:"term"`           // 开设学期
 }
 
 // CourseGroup 选课组信息
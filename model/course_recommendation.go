@@ -2,63 +2,223 @@ package model
 
 // CourseRecommendation 课程推荐数据结构
 type CourseRecommendation struct {
-	ID                   int64  `json:"id"`
-	CourseName           string `json:"course_name"`
-	TeacherName          string `json:"teacher_name"`
-	RecommendationReason string `json:"recommendation_reason"`
-	RecommenderNickname  string `json:"recommender_nickname"`
-	RecommendationTime   int64  `json:"recommendation_time"`
-	IsVisible            bool   `json:"is_visible"`
-	Campus               string `json:"campus"`              // 校区：曲阜/日照
-	RecommendationYear   string `json:"recommendation_year"` // 推荐依据年份
+	ID                   int64         `json:"id"`
+	CourseName           string        `json:"course_name"`
+	TeacherName          string        `json:"teacher_name"`
+	RecommendationReason string        `json:"recommendation_reason"`
+	RecommenderNickname  string        `json:"recommender_nickname"`
+	RecommendationTime   int64         `json:"recommendation_time"`
+	IsVisible            bool          `json:"is_visible"`
+	Campus               string        `json:"campus"`              // 校区：曲阜/日照
+	RecommendationYear   string        `json:"recommendation_year"` // 推荐依据年份
+	Ratings              CourseRatings `json:"ratings"`             // 多维度评分
+	Flagged              bool          `json:"flagged"`             // 是否被自动标记 (如命中敏感词)
+	FlagReason           string        `json:"flag_reason"`         // 标记原因
+	HelpfulCount         int           `json:"helpful_count"`       // 认为有用的人数
+	OpenReports          int           `json:"open_reports"`        // 待处理的举报数
+	Status               string        `json:"status"`              // 审核状态：pending / approved / rejected / hidden
+	Reviewer             string        `json:"reviewer"`            // 审核人
+	ReviewedAt           int64         `json:"reviewed_at"`         // 审核时间，未审核时为 0
+	RejectReason         string        `json:"reject_reason"`       // 拒绝或隐藏原因
+}
+
+// RecommendationClient 提交者的客户端信息，用于限流和去重
+type RecommendationClient struct {
+	IP          string
+	Fingerprint string
 }
 
 // CourseRecommendationPublic 对外展示的课程推荐（不包含 is_visible 字段）
 type CourseRecommendationPublic struct {
-	CourseName           string `json:"course_name"`
-	TeacherName          string `json:"teacher_name"`
-	RecommendationReason string `json:"recommendation_reason"`
-	RecommenderNickname  string `json:"recommender_nickname"`
-	RecommendationTime   int64  `json:"recommendation_time"`
-	Campus               string `json:"campus"`
-	RecommendationYear   string `json:"recommendation_year"`
+	ID                   int64         `json:"id"`
+	CourseName           string        `json:"course_name"`
+	TeacherName          string        `json:"teacher_name"`
+	RecommendationReason string        `json:"recommendation_reason"`
+	RecommenderNickname  string        `json:"recommender_nickname"`
+	RecommendationTime   int64         `json:"recommendation_time"`
+	Campus               string        `json:"campus"`
+	RecommendationYear   string        `json:"recommendation_year"`
+	Ratings              CourseRatings `json:"ratings"`
+	HelpfulCount         int           `json:"helpful_count"` // 认为有用的人数
+}
+
+// CourseRatings 多维度评分，每项 1-5 分，0 表示未评分
+type CourseRatings struct {
+	Difficulty           int `json:"difficulty" binding:"omitempty,min=1,max=5"`            // 课程难度，越高越难
+	Workload             int `json:"workload" binding:"omitempty,min=1,max=5"`              // 作业 / 任务量，越高越多
+	GradingLeniency      int `json:"grading_leniency" binding:"omitempty,min=1,max=5"`      // 给分宽松程度，越高越宽松
+	AttendanceStrictness int `json:"attendance_strictness" binding:"omitempty,min=1,max=5"` // 考勤严格程度，越高越严格
+	Overall              int `json:"overall" binding:"omitempty,min=1,max=5"`               // 综合推荐程度
+}
+
+// RatingAggregate 单个评分维度的统计
+type RatingAggregate struct {
+	Count        int     `json:"count"`        // 有效评分数量
+	Average      float64 `json:"average"`      // 平均分，没有评分时为 0
+	Distribution [5]int  `json:"distribution"` // 1-5 分各自的数量
+}
+
+// CourseRatingSummary 某门课程 (或某门课程 + 教师) 的评分汇总
+type CourseRatingSummary struct {
+	CourseName           string          `json:"course_name"`
+	TeacherName          string          `json:"teacher_name,omitempty"` // 按课程汇总时为空
+	RecommendationCount  int             `json:"recommendation_count"`   // 推荐条数
+	Difficulty           RatingAggregate `json:"difficulty"`
+	Workload             RatingAggregate `json:"workload"`
+	GradingLeniency      RatingAggregate `json:"grading_leniency"`
+	AttendanceStrictness RatingAggregate `json:"attendance_strictness"`
+	Overall              RatingAggregate `json:"overall"`
+}
+
+// CourseRecommendationQueryResponse 查询响应
+type CourseRecommendationQueryResponse struct {
+	List           []CourseRecommendationPublic `json:"list"`            // 当前页的推荐列表，按相关度排序
+	Total          int64                        `json:"total"`           // 符合条件的推荐总数
+	Page           int                          `json:"page"`            // 当前页码
+	PageSize       int                          `json:"page_size"`       // 每页条数
+	Courses        []CourseRatingSummary        `json:"courses"`         // 按课程汇总的评分 (所有符合条件的推荐)
+	CourseTeachers []CourseRatingSummary        `json:"course_teachers"` // 按课程 + 教师汇总的评分 (所有符合条件的推荐)
 }
 
 // CourseRecommendationQueryRequest 查询请求参数
 type CourseRecommendationQueryRequest struct {
-	Keyword string `form:"keyword" binding:"required"`
+	Keyword            string `form:"keyword" binding:"required"`                              // 匹配课程名称、教师姓名 (支持拼音及首字母) 和推荐理由
+	Campus             string `form:"campus"`                                                  // 校区，可选
+	RecommendationYear string `form:"recommendation_year"`                                     // 推荐依据年份，可选
+	MinRating          int    `form:"min_rating" binding:"omitempty,min=1,max=5"`              // 综合推荐评分下限，可选
+	Page               int    `form:"page" binding:"omitempty,min=1"`                          // 页码，默认 1
+	PageSize           int    `form:"page_size" binding:"omitempty,min=1,max=50"`              // 每页条数，默认 20
+	Sort               string `form:"sort" binding:"omitempty,oneof=relevance helpful latest"` // 排序方式：relevance 相关度 (默认)，helpful 有用人数，latest 最新
 }
 
 // CourseRecommendationRecommendRequest 推荐请求参数
 type CourseRecommendationRecommendRequest struct {
-	CourseName           string `json:"course_name" binding:"required"`
-	TeacherName          string `json:"teacher_name" binding:"required"`
-	RecommendationReason string `json:"recommendation_reason" binding:"required"`
-	RecommenderNickname  string `json:"recommender_nickname"` // 允许为空
-	Campus               string `json:"campus" binding:"required"`
-	RecommendationYear   string `json:"recommendation_year" binding:"required"`
+	CourseName           string        `json:"course_name" binding:"required"`
+	TeacherName          string        `json:"teacher_name" binding:"required"`
+	RecommendationReason string        `json:"recommendation_reason" binding:"required"`
+	RecommenderNickname  string        `json:"recommender_nickname"` // 允许为空
+	Campus               string        `json:"campus" binding:"required"`
+	RecommendationYear   string        `json:"recommendation_year" binding:"required"`
+	Ratings              CourseRatings `json:"ratings"` // 多维度评分，可选
 }
 
 // CourseRecommendationReviewRequest 审核请求参数
 type CourseRecommendationReviewRequest struct {
-	RecommendationID int64 `json:"recommendation_id" binding:"required"`
-	IsVisible        bool  `json:"is_visible"`
+	RecommendationID int64  `json:"recommendation_id" binding:"required"`
+	Status           string `json:"status" binding:"omitempty,oneof=pending approved rejected hidden"` // 目标状态，为空时按 is_visible 处理
+	IsVisible        bool   `json:"is_visible"`                                                        // 兼容旧版：true 通过，false 隐藏
+	RejectReason     string `json:"reject_reason"`                                                     // 拒绝原因，status 为 rejected 时必填
+}
+
+// CourseRecommendationStatusRequest 投稿审核状态查询参数
+type CourseRecommendationStatusRequest struct {
+	Token string `form:"token" binding:"required"` // 提交推荐时返回的状态查询凭证
+}
+
+// CourseRecommendationStatusResponse 投稿审核状态
+type CourseRecommendationStatusResponse struct {
+	CourseName         string `json:"course_name"`
+	TeacherName        string `json:"teacher_name"`
+	RecommendationTime int64  `json:"recommendation_time"`
+	Status             string `json:"status"`        // pending / approved / rejected / hidden
+	ReviewedAt         int64  `json:"reviewed_at"`   // 审核时间，未审核时为 0
+	RejectReason       string `json:"reject_reason"` // 拒绝或隐藏原因
 }
 
 // CourseRecommendationUpdateRequest 更新请求参数（管理员）
 type CourseRecommendationUpdateRequest struct {
-	RecommendationID     int64  `json:"recommendation_id" binding:"required"`
-	CourseName           string `json:"course_name" binding:"required"`
-	TeacherName          string `json:"teacher_name" binding:"required"`
-	RecommendationReason string `json:"recommendation_reason" binding:"required"`
-	RecommenderNickname  string `json:"recommender_nickname"`
-	IsVisible            bool   `json:"is_visible"`
-	Campus               string `json:"campus" binding:"required"`
-	RecommendationYear   string `json:"recommendation_year" binding:"required"`
+	RecommendationID     int64         `json:"recommendation_id" binding:"required"`
+	CourseName           string        `json:"course_name" binding:"required"`
+	TeacherName          string        `json:"teacher_name" binding:"required"`
+	RecommendationReason string        `json:"recommendation_reason" binding:"required"`
+	RecommenderNickname  string        `json:"recommender_nickname"`
+	IsVisible            bool          `json:"is_visible"`
+	Status               string        `json:"status" binding:"omitempty,oneof=pending approved rejected hidden"` // 目标状态，为空时按 is_visible 处理
+	Campus               string        `json:"campus" binding:"required"`
+	RecommendationYear   string        `json:"recommendation_year" binding:"required"`
+	Ratings              CourseRatings `json:"ratings"`
 }
 
 // CourseRecommendationRecommendResponse 推荐成功响应
 type CourseRecommendationRecommendResponse struct {
 	Message            string `json:"message"`
 	RecommendationTime int64  `json:"recommendation_time"`
+	StatusToken        string `json:"status_token"` // 审核状态查询凭证，只在提交时返回一次
+}
+
+// RecommendationEntity 规范课程或教师，自由填写的名称通过别名关联到同一实体
+type RecommendationEntity struct {
+	ID                  int64    `json:"id"`
+	Name                string   `json:"name"`                 // 规范名称
+	Aliases             []string `json:"aliases"`              // 所有别名，包含规范名称本身
+	RecommendationCount int      `json:"recommendation_count"` // 关联的推荐条数
+	CreatedAt           int64    `json:"created_at"`
+}
+
+// RecommendationEntityListRequest 规范课程 / 教师列表请求参数（管理员）
+type RecommendationEntityListRequest struct {
+	Kind    string `form:"kind" binding:"required,oneof=course teacher"` // course 课程，teacher 教师
+	Keyword string `form:"keyword"`                                      // 按名称或别名过滤，可选
+}
+
+// RecommendationEntityMergeRequest 合并重复实体请求参数（管理员）
+type RecommendationEntityMergeRequest struct {
+	Kind      string  `json:"kind" binding:"required,oneof=course teacher"`
+	TargetID  int64   `json:"target_id" binding:"required"`        // 保留的实体
+	SourceIDs []int64 `json:"source_ids" binding:"required,min=1"` // 被合并的实体，合并后删除
+}
+
+// RecommendationEntityRenameRequest 重命名实体请求参数（管理员）
+type RecommendationEntityRenameRequest struct {
+	Kind string `json:"kind" binding:"required,oneof=course teacher"`
+	ID   int64  `json:"id" binding:"required"`
+	Name string `json:"name" binding:"required"`
+}
+
+// RecommendationEntityAliasRequest 添加 / 删除别名请求参数（管理员）
+type RecommendationEntityAliasRequest struct {
+	Kind  string `json:"kind" binding:"required,oneof=course teacher"`
+	ID    int64  `json:"id" binding:"required"`
+	Alias string `json:"alias" binding:"required"`
+}
+
+// RecommendationHelpfulRequest 标记推荐有用请求参数
+type RecommendationHelpfulRequest struct {
+	RecommendationID int64 `json:"recommendation_id" binding:"required"`
+}
+
+// RecommendationHelpfulResponse 标记推荐有用响应
+type RecommendationHelpfulResponse struct {
+	HelpfulCount int  `json:"helpful_count"` // 最新的有用人数
+	AlreadyVoted bool `json:"already_voted"` // 此前是否已标记过
+}
+
+// RecommendationReportRequest 举报推荐请求参数
+type RecommendationReportRequest struct {
+	RecommendationID int64  `json:"recommendation_id" binding:"required"`
+	Reason           string `json:"reason" binding:"required,max=200"` // 举报原因
+}
+
+// RecommendationReport 举报记录（管理员）
+type RecommendationReport struct {
+	ID               int64  `json:"id"`
+	RecommendationID int64  `json:"recommendation_id"`
+	Reason           string `json:"reason"`
+	Status           string `json:"status"` // open 待处理，resolved 已处理，dismissed 已驳回
+	Resolution       string `json:"resolution"`
+	CreatedAt        int64  `json:"created_at"`
+	ResolvedAt       int64  `json:"resolved_at"`
+
+	// 被举报推荐的内容，推荐已删除时为空
+	CourseName           string `json:"course_name"`
+	TeacherName          string `json:"teacher_name"`
+	RecommendationReason string `json:"recommendation_reason"`
+	IsVisible            bool   `json:"is_visible"`
+}
+
+// RecommendationReportResolveRequest 处理举报请求参数（管理员）
+type RecommendationReportResolveRequest struct {
+	ReportID   int64  `json:"report_id" binding:"required"`
+	Action     string `json:"action" binding:"required,oneof=dismiss hide delete"` // dismiss 驳回，hide 隐藏推荐，delete 删除推荐
+	Resolution string `json:"resolution"`                                          // 处理说明，可选
 }
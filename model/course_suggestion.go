@@ -0,0 +1,36 @@
+package model
+
+// CourseSuggestionRequest 下学期选课建议请求参数
+type CourseSuggestionRequest struct {
+	Term     string `form:"term"`     // 当前学年学期，为空时根据当前日期推断
+	Semester int    `form:"semester"` // 当前是第几学期，为空时根据成绩推断入学年份后计算
+}
+
+// CourseSuggestion 一门建议修读的课程
+type CourseSuggestion struct {
+	CourseCode      string                       `json:"course_code"`     // 课程编号
+	CourseName      string                       `json:"course_name"`     // 课程名称
+	GroupName       string                       `json:"group_name"`      // 所属选课组
+	CourseProp      string                       `json:"course_prop"`     // 课程性质
+	CourseAttr      string                       `json:"course_attr"`     // 课程属性
+	Credits         float64                      `json:"credits"`         // 学分
+	Term            string                       `json:"term"`            // 培养方案中的开设学期
+	Recommendations []CourseRecommendationPublic `json:"recommendations"` // 选课推荐模块中已审核通过的推荐
+}
+
+// ElectiveGroupSuggestion 学分未修满的选修课组
+type ElectiveGroupSuggestion struct {
+	GroupName        string             `json:"group_name"`        // 选课组名称
+	RequiredCredits  float64            `json:"required_credits"`  // 应修学分
+	EarnedCredits    float64            `json:"earned_credits"`    // 已修学分
+	RemainingCredits float64            `json:"remaining_credits"` // 尚差学分
+	Candidates       []CourseSuggestion `json:"candidates"`        // 组内尚未修读的课程，下学期开设的排在前面
+}
+
+// CourseSuggestionResponse 下学期选课建议响应结构
+type CourseSuggestionResponse struct {
+	CurrentSemester int                       `json:"current_semester"` // 当前是第几学期
+	NextSemester    int                       `json:"next_semester"`    // 下学期是第几学期
+	Courses         []CourseSuggestion        `json:"courses"`          // 下学期开设、尚未通过的必修课
+	ElectiveGroups  []ElectiveGroupSuggestion `json:"elective_groups"`  // 学分未修满的选修课组
+}
@@ -0,0 +1,37 @@
+package model
+
+// ElectiveCatalogRequest 选课课程目录查询参数
+type ElectiveCatalogRequest struct {
+	Kind     string `form:"kind"`      // 课程类别：public(公选课) / elective(专业选修) / required(必修) / plan(本学期计划) / cross_grade(跨年级) / cross_major(跨专业)，默认 public
+	Keyword  string `form:"keyword"`   // 课程名称或编号关键词
+	Teacher  string `form:"teacher"`   // 授课教师
+	Day      int    `form:"day"`       // 上课星期 (1-7)，0 表示不限
+	Period   int    `form:"period"`    // 上课节次，0 表示不限
+	Campus   string `form:"campus"`    // 校区名称关键词，如 "曲阜"、"日照"；"auto" 表示使用学籍信息中的校区
+	Page     int    `form:"page"`      // 页码，从 1 开始
+	PageSize int    `form:"page_size"` // 每页数量，默认 50
+}
+
+// ElectiveCourse 选课目录中的一个教学班
+type ElectiveCourse struct {
+	ClassID         string                       `json:"class_id"`        // 教学班 ID，对应 upstream: jx0404id
+	CourseCode      string                       `json:"course_code"`     // 课程编号
+	CourseName      string                       `json:"course_name"`     // 课程名称
+	Credit          string                       `json:"credit"`          // 学分
+	Teacher         string                       `json:"teacher"`         // 授课教师
+	TimeText        string                       `json:"time_text"`       // 原始上课时间
+	Times           []ClassTimeParse             `json:"times"`           // 解析后的上课时间
+	Location        string                       `json:"location"`        // 上课地点
+	Campus          string                       `json:"campus"`          // 校区
+	Capacity        int                          `json:"capacity"`        // 容量
+	Selected        int                          `json:"selected"`        // 已选人数
+	Remaining       int                          `json:"remaining"`       // 剩余名额
+	Recommendations []CourseRecommendationPublic `json:"recommendations"` // 选课推荐模块中已审核通过的推荐
+}
+
+// ElectiveCatalogResponse 选课课程目录查询结果
+type ElectiveCatalogResponse struct {
+	Kind    string           `json:"kind"`    // 课程类别
+	Total   int              `json:"total"`   // 符合条件的总记录数，按校区或时间段筛选时为本地筛选后的数量
+	Courses []ElectiveCourse `json:"courses"` // 课程列表
+}
@@ -0,0 +1,18 @@
+package model
+
+// EvaluationTask 一项评教任务
+type EvaluationTask struct {
+	Term       string `json:"term"`        // 学年学期
+	Batch      string `json:"batch"`       // 评价批次
+	CourseCode string `json:"course_code"` // 课程编号
+	CourseName string `json:"course_name"` // 课程名称
+	Teacher    string `json:"teacher"`     // 授课教师
+	Deadline   string `json:"deadline"`    // 截止时间
+	Submitted  bool   `json:"submitted"`   // 是否已提交
+}
+
+// EvaluationResponse 评教任务查询结果 (只读，不会代为评教)
+type EvaluationResponse struct {
+	PendingCount int              `json:"pending_count"` // 未提交的评教任务数量
+	Tasks        []EvaluationTask `json:"tasks"`         // 评教任务，未提交的排在前面
+}
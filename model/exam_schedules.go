@@ -15,10 +15,29 @@ type ExamSchedule struct {
 	AdmissionNo string `json:"admission_no"` // 准考证号
 	Remarks     string `json:"remarks"`      // 备注
 	Operation   string `json:"operation"`    // 操作
+
+	StartTime     int64  `json:"start_time"`               // 考试开始时间 (Unix 时间戳)，无法解析时为 0
+	EndTime       int64  `json:"end_time"`                 // 考试结束时间 (Unix 时间戳)，无法解析时为 0
+	DaysRemaining *int   `json:"days_remaining,omitempty"` // 距离考试的天数，0 为今天，已结束为负数；考试时间无法解析时不返回
+	Status        string `json:"status"`                   // 考试状态：upcoming / ongoing / finished / unknown
 }
 
+// 考试状态
+const (
+	ExamStatusUpcoming = "upcoming" // 未开始
+	ExamStatusOngoing  = "ongoing"  // 进行中
+	ExamStatusFinished = "finished" // 已结束
+	ExamStatusUnknown  = "unknown"  // 考试时间无法解析
+)
+
 // ExamSchedulesRequest 定义前端查询参数
 // Gin 使用 "form" tag 来解析 Query String (?term=...)
 type ExamSchedulesRequest struct {
 	Term string `form:"term"` // 学期，对应 upstream: kksj
 }
+
+// ExamCalendarRequest 考试安排日历导出参数
+type ExamCalendarRequest struct {
+	Term   string `form:"term"`   // 学期，对应 upstream: kksj
+	Alarms string `form:"alarms"` // 提醒时间，逗号分隔，如 "1d,2h,30m"，为空时使用默认的提前 1 天和 2 小时
+}
@@ -0,0 +1,28 @@
+package model
+
+// FreeClassroomRequest 空教室查询参数
+type FreeClassroomRequest struct {
+	Date        string `form:"date" binding:"required"`         // 日期 (e.g., 2026-03-02)
+	Term        string `form:"term"`                            // 学年学期，为空时根据日期推断，对应 upstream: xnxqh
+	StartPeriod int    `form:"start_period" binding:"required"` // 开始节次
+	EndPeriod   int    `form:"end_period"`                      // 结束节次，为空时等于开始节次
+	Campus      string `form:"campus"`                          // 校区编号，对应 upstream: xqbh
+	Building    string `form:"building"`                        // 教学楼编号，对应 upstream: jxlbh
+}
+
+// FreeClassroom 空闲教室信息
+type FreeClassroom struct {
+	Name     string `json:"name"`     // 教室名称
+	Capacity int    `json:"capacity"` // 座位数，无法解析时为 0
+}
+
+// FreeClassroomResponse 空教室查询响应结构
+type FreeClassroomResponse struct {
+	Date        string          `json:"date"`         // 查询日期
+	Term        string          `json:"term"`         // 学年学期
+	Week        int             `json:"week"`         // 教学周
+	DayOfWeek   int             `json:"day_of_week"`  // 星期几 (1-7)
+	StartPeriod int             `json:"start_period"` // 开始节次
+	EndPeriod   int             `json:"end_period"`   // 结束节次
+	Rooms       []FreeClassroom `json:"rooms"`        // 空闲教室列表
+}
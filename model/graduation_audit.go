@@ -0,0 +1,46 @@
+package model
+
+// 毕业审核结论
+const (
+	AuditVerdictOnTrack = "on_track" // 按计划推进
+	AuditVerdictAtRisk  = "at_risk"  // 存在风险
+)
+
+// GraduationAuditRequest 毕业审核请求参数
+type GraduationAuditRequest struct {
+	Term string `form:"term"` // 当前学年学期，用于读取本学期选课结果，为空时根据当前日期推断
+}
+
+// UnpassedCourse 尚未通过的必修课
+type UnpassedCourse struct {
+	CourseCode string  `json:"course_code"` // 课程编号
+	CourseName string  `json:"course_name"` // 课程名称
+	Credits    float64 `json:"credits"`     // 学分
+	Term       string  `json:"term"`        // 开设学期 (第几学期)
+	Failed     bool    `json:"failed"`      // 是否有不及格记录
+	Selected   bool    `json:"selected"`    // 本学期是否已选
+	Overdue    bool    `json:"overdue"`     // 开设学期已过但仍未通过
+}
+
+// GroupAudit 单个选课组的审核结果
+type GroupAudit struct {
+	GroupName            string           `json:"group_name"`             // 选课组名称
+	RequiredCredits      float64          `json:"required_credits"`       // 应修学分
+	EarnedCredits        float64          `json:"earned_credits"`         // 已修学分
+	RemainingCredits     float64          `json:"remaining_credits"`      // 尚差学分
+	SelectedCredits      float64          `json:"selected_credits"`       // 本学期已选、尚未出成绩的学分
+	Satisfied            bool             `json:"satisfied"`              // 是否已修满
+	CompletedBySelection bool             `json:"completed_by_selection"` // 本学期所选课程通过后即可修满
+	UnpassedRequired     []UnpassedCourse `json:"unpassed_required"`      // 尚未通过的必修课
+}
+
+// GraduationAuditResponse 毕业审核响应结构
+type GraduationAuditResponse struct {
+	Verdict          string       `json:"verdict"`           // 审核结论：on_track / at_risk
+	Reasons          []string     `json:"reasons"`           // 存在风险的原因
+	CurrentSemester  int          `json:"current_semester"`  // 当前是第几学期，无法推断时为 0
+	RequiredCredits  float64      `json:"required_credits"`  // 应修总学分
+	EarnedCredits    float64      `json:"earned_credits"`    // 已修总学分
+	RemainingCredits float64      `json:"remaining_credits"` // 尚差总学分
+	Groups           []GroupAudit `json:"groups"`            // 各选课组审核结果
+}
@@ -0,0 +1,15 @@
+package model
+
+// LevelExamScore 等级考试成绩 (四六级、计算机等级考试等)
+type LevelExamScore struct {
+	ExamName   string              `json:"exam_name"`   // 考试名称，如 "全国大学英语四级"
+	ExamDate   string              `json:"exam_date"`   // 考试时间
+	TotalScore string              `json:"total_score"` // 总成绩，部分考试为等级 (如 "合格")
+	SubScores  []LevelExamSubScore `json:"sub_scores"`  // 分项成绩，如笔试、机试、听力
+}
+
+// LevelExamSubScore 等级考试分项成绩
+type LevelExamSubScore struct {
+	Name  string `json:"name"`  // 分项名称
+	Score string `json:"score"` // 分项成绩
+}
@@ -0,0 +1,39 @@
+package model
+
+// NewsListRequest 通知公告列表查询参数
+type NewsListRequest struct {
+	Page     int    `form:"page"`      // 页码，从 1 开始
+	PageSize int    `form:"page_size"` // 每页数量，默认 20，最多 100
+	Keyword  string `form:"keyword"`   // 标题关键词
+}
+
+// NewsItem 通知公告列表项
+type NewsItem struct {
+	ID          string `json:"id"`           // 公告 ID，取自原文链接，如 1064-12345
+	Title       string `json:"title"`        // 标题
+	URL         string `json:"url"`          // 原文链接
+	PublishedAt string `json:"published_at"` // 发布日期，格式 2006-01-02
+	FetchedAt   int64  `json:"fetched_at"`   // 首次抓取时间
+}
+
+// NewsAttachment 通知公告附件
+type NewsAttachment struct {
+	Name string `json:"name"` // 附件名称
+	URL  string `json:"url"`  // 下载链接
+}
+
+// NewsDetail 通知公告详情
+type NewsDetail struct {
+	NewsItem
+	Content     string           `json:"content"`     // 正文纯文本，段落之间以换行分隔
+	Attachments []NewsAttachment `json:"attachments"` // 附件
+}
+
+// NewsListResponse 通知公告列表响应
+type NewsListResponse struct {
+	List          []NewsItem `json:"list"`
+	Total         int64      `json:"total"`
+	Page          int        `json:"page"`
+	PageSize      int        `json:"page_size"`
+	LastRefreshed int64      `json:"last_refreshed"` // 最近一次成功抓取列表的时间，0 表示从未成功
+}
@@ -0,0 +1,39 @@
+package model
+
+// 概览各部分的状态
+const (
+	OverviewStatusOK    = "ok"    // 获取成功
+	OverviewStatusError = "error" // 获取失败，其余部分不受影响
+)
+
+// OverviewSection 概览中的一个部分，失败时 Data 为空并给出错误码和原因
+type OverviewSection[T any] struct {
+	Status string `json:"status"`          // ok / error
+	Code   int    `json:"code,omitempty"`  // 失败时的错误码，与接口错误码一致
+	Error  string `json:"error,omitempty"` // 失败原因
+	Data   *T     `json:"data"`            // 数据
+}
+
+// OverviewGPA 绩点概览
+type OverviewGPA struct {
+	Total  GradeStat     `json:"total"`  // 总体统计
+	Latest *SemesterStat `json:"latest"` // 最近一个学期的统计，没有成绩时为空
+}
+
+// OverviewToday 今日课程
+type OverviewToday struct {
+	Date        string           `json:"date"`               // 日期
+	CurrentWeek int              `json:"current_week"`       // 当前教学周
+	DayOfWeek   int              `json:"day_of_week"`        // 星期几 (1-7)
+	InTerm      bool             `json:"in_term"`            // 是否在教学周历内
+	Courses     []ClassSchedules `json:"courses"`            // 今天的课程
+	Calendar    *CalendarDay     `json:"calendar,omitempty"` // 校历信息 (放假、调休补课)
+}
+
+// OverviewResponse 个人概览，各部分独立获取，部分失败不影响其他部分
+type OverviewResponse struct {
+	GPA       OverviewSection[OverviewGPA]              `json:"gpa"`       // 绩点概览
+	Today     OverviewSection[OverviewToday]            `json:"today"`     // 今日课程
+	Exams     OverviewSection[[]ExamSchedule]           `json:"exams"`     // 未结束的考试
+	Selection OverviewSection[SelectionResultsResponse] `json:"selection"` // 本学期选课结果
+}
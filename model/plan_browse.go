@@ -0,0 +1,61 @@
+package model
+
+// PlanOption 培养方案查询的下拉选项
+type PlanOption struct {
+	Value string `json:"value"` // 选项值，作为查询参数使用
+	Label string `json:"label"` // 显示名称
+}
+
+// PlanOptionsResponse 培养方案查询可选的年级和专业
+type PlanOptionsResponse struct {
+	Grades []PlanOption `json:"grades"` // 年级
+	Majors []PlanOption `json:"majors"` // 专业
+}
+
+// PlanSearchRequest 培养方案查询参数，年级和专业都为空时使用学籍信息中的年级
+type PlanSearchRequest struct {
+	Grade string `form:"grade"` // 年级，对应 upstream: nj
+	Major string `form:"major"` // 专业编号，对应 upstream: zyh
+}
+
+// PlanSummary 培养方案列表项
+type PlanSummary struct {
+	PlanID  string `json:"plan_id"` // 培养方案 ID，对应 upstream: pyfaid
+	Name    string `json:"name"`    // 培养方案名称
+	Grade   string `json:"grade"`   // 年级
+	College string `json:"college"` // 院系
+	Major   string `json:"major"`   // 专业
+}
+
+// PlanDetailRequest 培养方案详情 / 对比请求参数
+type PlanDetailRequest struct {
+	PlanID string `form:"plan_id" binding:"required"` // 培养方案 ID
+}
+
+// PlanCompareCourse 对比结果中的课程
+type PlanCompareCourse struct {
+	CourseCode string  `json:"course_code"` // 课程编号
+	CourseName string  `json:"course_name"` // 课程名称
+	Credits    float64 `json:"credits"`     // 学分
+	CourseAttr string  `json:"course_attr"` // 课程属性
+}
+
+// PlanCompareGroup 目标培养方案中一个选课组的对比结果
+type PlanCompareGroup struct {
+	GroupName        string              `json:"group_name"`        // 选课组名称
+	RequiredCredits  float64             `json:"required_credits"`  // 应修学分
+	CarriedCredits   float64             `json:"carried_credits"`   // 已修课程可认定的学分 (不超过应修学分)
+	RemainingCredits float64             `json:"remaining_credits"` // 尚差学分
+	CarriedCourses   []PlanCompareCourse `json:"carried_courses"`   // 可认定的已修课程
+	MissingRequired  []PlanCompareCourse `json:"missing_required"`  // 尚未修读的必修课
+}
+
+// PlanCompareResponse 当前修读记录与目标培养方案的对比结果
+type PlanCompareResponse struct {
+	PlanID           string              `json:"plan_id"`           // 目标培养方案 ID
+	RequiredCredits  float64             `json:"required_credits"`  // 目标方案应修总学分
+	CarriedCredits   float64             `json:"carried_credits"`   // 可认定的总学分
+	RemainingCredits float64             `json:"remaining_credits"` // 尚差总学分
+	Groups           []PlanCompareGroup  `json:"groups"`            // 各选课组对比结果
+	Uncounted        []PlanCompareCourse `json:"uncounted"`         // 已修但目标方案中没有的课程
+}
@@ -0,0 +1,47 @@
+package model
+
+// PlannerCandidate 一个候选教学班，字段与选课目录中的 ElectiveCourse 保持一致，可以直接传入
+type PlannerCandidate struct {
+	ClassID    string           `json:"class_id"`    // 教学班 ID，手动录入时可为空
+	CourseName string           `json:"course_name"` // 课程名称，同名的候选视为同一门课的不同教学班，只会选其一
+	Teacher    string           `json:"teacher"`     // 授课教师
+	Campus     string           `json:"campus"`      // 校区
+	Location   string           `json:"location"`    // 上课地点
+	Times      []ClassTimeParse `json:"times"`       // 上课时间，Weeks 为空表示全部周次
+	Optional   bool             `json:"optional"`    // 是否可以不选这门课，同一门课任一教学班标记即生效
+}
+
+// PlannerPreferences 排课偏好
+type PlannerPreferences struct {
+	NoEarlyClasses     bool  `json:"no_early_classes"`     // 尽量避免早八 (第 1-2 节)
+	FreeDays           []int `json:"free_days"`            // 希望空出来的星期，如 [5] 表示周五没课
+	MinimiseCampusHops bool  `json:"minimise_campus_hops"` // 尽量减少同一天内跨校区 / 跨楼
+}
+
+// PlannerRequest 无冲突课表方案规划请求
+type PlannerRequest struct {
+	Term        string             `json:"term"`         // 学年学期，用于获取已有课表，为空时为当前学期
+	Candidates  []PlannerCandidate `json:"candidates"`   // 候选教学班，最多 15 门课程，每门课最多 30 个教学班
+	Preferences PlannerPreferences `json:"preferences"`  // 排课偏好
+	IgnoreFixed bool               `json:"ignore_fixed"` // 是否忽略已有课表，只在候选课程之间排课
+	MaxResults  int                `json:"max_results"`  // 返回方案数量，默认 10，最多 50
+}
+
+// PlannerPlan 一个无冲突的选课方案
+type PlannerPlan struct {
+	Rank           int                `json:"rank"`             // 排名
+	Selections     []PlannerCandidate `json:"selections"`       // 选中的教学班
+	Skipped        []string           `json:"skipped"`          // 未选的可选课程
+	EarlyClasses   int                `json:"early_classes"`    // 早八的时间段数量
+	FreeDayClasses int                `json:"free_day_classes"` // 落在希望空出来的星期的时间段数量
+	CampusHops     int                `json:"campus_hops"`      // 同一天内相邻两次课地点不同的次数
+	Score          float64            `json:"score"`            // 偏好得分，越高越好
+}
+
+// PlannerResponse 无冲突课表方案规划结果
+type PlannerResponse struct {
+	FixedCourses int           `json:"fixed_courses"` // 已有课表中的课程数量
+	Explored     int           `json:"explored"`      // 搜索过的节点数量 (含因冲突提前结束的分支)
+	Truncated    bool          `json:"truncated"`     // 组合过多时搜索是否被提前截断
+	Plans        []PlannerPlan `json:"plans"`         // 按偏好排序的方案
+}
@@ -0,0 +1,22 @@
+package model
+
+// StudentProfileRequest 学籍信息查询参数
+type StudentProfileRequest struct {
+	Reveal bool `form:"reveal"` // 是否返回未脱敏的敏感字段，默认脱敏
+}
+
+// StudentProfile 学籍信息
+type StudentProfile struct {
+	StudentID string `json:"student_id"` // 学号
+	Name      string `json:"name"`       // 姓名
+	Gender    string `json:"gender"`     // 性别
+	College   string `json:"college"`    // 院系
+	Major     string `json:"major"`      // 专业
+	Grade     string `json:"grade"`      // 年级，如 "2023"
+	Class     string `json:"class"`      // 班级
+	Campus    string `json:"campus"`     // 校区
+	EntryDate string `json:"entry_date"` // 入学日期
+	IDNumber  string `json:"id_number"`  // 身份证号，默认脱敏
+	Phone     string `json:"phone"`      // 联系电话，默认脱敏
+	Masked    bool   `json:"masked"`     // 敏感字段是否已脱敏
+}
@@ -13,6 +13,17 @@ type SelectionResult struct {
 	CourseProp string `json:"course_prop"` // 课程性质
 	Operator   string `json:"operator"`    // 选课操作人
 	SelectTime string `json:"select_time"` // 选课时间
+
+	Slots []SelectionSlot `json:"slots,omitempty"` // 课表中对应的上课时间地点，课表不可用时为空
+}
+
+// SelectionSlot 选课结果在课表中的一个上课时段
+type SelectionSlot struct {
+	DayOfWeek     int    `json:"day_of_week"`     // 星期几 (1-7)
+	Periods       []int  `json:"periods"`         // 节次
+	Weeks         []int  `json:"weeks"`           // 上课周次
+	Location      string `json:"location"`        // 上课地点
+	RawTimeString string `json:"raw_time_string"` // 原始时间字符串
 }
 
 //  SelectionResultsRequest 定义前端查询参数
@@ -24,4 +35,38 @@ type SelectionResultsRequest struct {
 // SelectionResultsResponse 选课结果查询响应结构
 type SelectionResultsResponse struct {
 	Results []SelectionResult `json:"results"` // 选课结果列表
+	Summary SelectionSummary  `json:"summary"` // 学分学时统计
+}
+
+// 选课学分与学期限制的比较结果
+const (
+	CreditLimitOK    = "ok"    // 在限制范围内
+	CreditLimitBelow = "below" // 低于学分下限
+	CreditLimitAbove = "above" // 超过学分上限
+)
+
+// SelectionSummary 选课结果统计
+type SelectionSummary struct {
+	CourseCount     int                   `json:"course_count"`     // 课程门数
+	TotalCredits    float64               `json:"total_credits"`    // 总学分
+	TotalHours      float64               `json:"total_hours"`      // 总学时
+	ByCourseProp    []SelectionGroupTotal `json:"by_course_prop"`   // 按课程性质统计
+	ByCourseAttr    []SelectionGroupTotal `json:"by_course_attr"`   // 按课程属性统计
+	CreditLimit     SelectionCreditLimit  `json:"credit_limit"`     // 学期学分限制比较
+	ScheduleMatched bool                  `json:"schedule_matched"` // 是否已与课表匹配上课时段
+}
+
+// SelectionGroupTotal 某一课程性质 / 属性下的学分学时合计
+type SelectionGroupTotal struct {
+	Name    string  `json:"name"`    // 课程性质或属性
+	Count   int     `json:"count"`   // 课程门数
+	Credits float64 `json:"credits"` // 学分合计
+	Hours   float64 `json:"hours"`   // 学时合计
+}
+
+// SelectionCreditLimit 选课总学分与学期学分限制的比较
+type SelectionCreditLimit struct {
+	MinCredits float64 `json:"min_credits"` // 学分下限，0 表示不限制
+	MaxCredits float64 `json:"max_credits"` // 学分上限，0 表示不限制
+	Status     string  `json:"status"`      // ok / below / above
 }
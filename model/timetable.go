@@ -0,0 +1,21 @@
+package model
+
+// TimetableSearchRequest 公共课表查询参数 (教师 / 班级 / 教室)
+type TimetableSearchRequest struct {
+	Name string `form:"name" binding:"required"` // 教师姓名 / 班级名称 / 教室名称
+	Term string `form:"term" binding:"required"` // 学年学期，对应 upstream: xnxqh
+	Week int    `form:"week"`                    // 周次，为空时查询整个学期
+}
+
+// TimetableOwner 一个教师 / 班级 / 教室的课表
+type TimetableOwner struct {
+	Name    string           `json:"name"`    // 教师姓名 / 班级名称 / 教室名称
+	Courses []ClassSchedules `json:"courses"` // 课程列表，与个人课表结构一致
+}
+
+// TimetableSearchResponse 公共课表查询响应结构
+type TimetableSearchResponse struct {
+	Term    string           `json:"term"`    // 学年学期
+	Week    int              `json:"week"`    // 周次，0 表示整个学期
+	Results []TimetableOwner `json:"results"` // 匹配到的课表列表
+}
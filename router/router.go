@@ -3,10 +3,15 @@ package router
 import (
 	"embed"
 	"io/fs"
+	"log/slog"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/W1ndys/easy-qfnu-api-go/api/v1/admin"
+	"github.com/W1ndys/easy-qfnu-api-go/api/v1/calendar"
 	course_recommendation "github.com/W1ndys/easy-qfnu-api-go/api/v1/course_recommendation"
+	"github.com/W1ndys/easy-qfnu-api-go/api/v1/news"
 	"github.com/W1ndys/easy-qfnu-api-go/api/v1/questions"
 	"github.com/W1ndys/easy-qfnu-api-go/api/v1/site"
 	"github.com/W1ndys/easy-qfnu-api-go/api/v1/stats"
@@ -20,6 +25,9 @@ import (
 func InitRouter(webFS embed.FS) *gin.Engine {
 	r := gin.Default()
 
+	// 0. 设置可信代理，决定 ClientIP 是否采信 X-Forwarded-For
+	installTrustedProxies(r)
+
 	// 1. 注册中间件
 	installMiddlewares(r)
 
@@ -32,6 +40,21 @@ func InitRouter(webFS embed.FS) *gin.Engine {
 	return r
 }
 
+// installTrustedProxies 只信任环境变量 TRUSTED_PROXIES 中列出的反向代理 (逗号分隔的 IP 或 CIDR)
+// 未配置时不信任任何代理，ClientIP 直接使用连接的远端地址，避免客户端伪造 X-Forwarded-For 绕过按 IP 的限流
+func installTrustedProxies(r *gin.Engine) {
+	var proxies []string
+	for _, p := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	if err := r.SetTrustedProxies(proxies); err != nil {
+		slog.Warn("TRUSTED_PROXIES 配置无效，不信任任何代理", "error", err)
+		r.SetTrustedProxies(nil)
+	}
+}
+
 func installMiddlewares(r *gin.Engine) {
 	r.Use(middleware.Recovery())
 	r.Use(middleware.RequestLogger())
@@ -55,8 +78,12 @@ func installAPIRoutes(r *gin.Engine) {
 	// 【公开接口组】 (Public)
 	// 特点：不挂载 AuthRequired 中间件
 	{
-		// apiV1.GET("/news", v1.GetNewsList)
-		// apiV1.GET("/calendar", v1.GetCalendar)
+		// 教务处通知公告
+		apiV1.GET("/news", news.GetNewsList)
+		apiV1.GET("/news/:id", news.GetNewsDetail)
+
+		// 校历：日期 -> 学期、教学周、当天是否上课
+		apiV1.GET("/calendar", calendar.GetCalendar)
 
 		// 新生试题库搜索
 		apiV1.GET("/questions/search", questions.Search)
@@ -78,6 +105,9 @@ func installAPIRoutes(r *gin.Engine) {
 		{
 			courseRecGroup.GET("/query", course_recommendation.Query)
 			courseRecGroup.POST("/recommend", course_recommendation.Recommend)
+			courseRecGroup.POST("/helpful", course_recommendation.MarkHelpful)
+			courseRecGroup.POST("/report", course_recommendation.Report)
+			courseRecGroup.GET("/status", course_recommendation.Status)
 		}
 	}
 
@@ -86,16 +116,46 @@ func installAPIRoutes(r *gin.Engine) {
 	zhjwGroup := apiV1.Group("/zhjw")
 	zhjwGroup.Use(middleware.AuthRequired())
 	{
+		// 首页个人概览 (并发获取绩点、今日课程、考试、选课)
+		zhjwGroup.GET("/overview", zhjw.GetOverview)
 		// 成绩相关接口
 		zhjwGroup.GET("/grade", zhjw.GetGradeList)
+		// 等级考试成绩 (四六级、计算机等级考试)
+		zhjwGroup.GET("/level-exam", zhjw.GetLevelExamScores)
 		// 教学计划/培养方案
 		zhjwGroup.GET("/course-plan", zhjw.GetCoursePlan)
+		// 学籍信息
+		zhjwGroup.GET("/profile", zhjw.GetStudentProfile)
+		// 评教任务 (只读)
+		zhjwGroup.GET("/evaluation", zhjw.GetEvaluationTasks)
+		// 毕业审核
+		zhjwGroup.GET("/graduation-audit", zhjw.GetGraduationAudit)
+		// 下学期选课建议
+		zhjwGroup.GET("/course-suggestions", zhjw.GetCourseSuggestions)
+		// 其他专业培养方案浏览与对比 (辅修 / 转专业)
+		zhjwGroup.GET("/plans/options", zhjw.GetPlanOptions)
+		zhjwGroup.GET("/plans", zhjw.SearchPlans)
+		zhjwGroup.GET("/plans/detail", zhjw.GetPlanDetail)
+		zhjwGroup.GET("/plans/compare", zhjw.ComparePlan)
 		// 考试安排相关接口
 		zhjwGroup.GET("/exam", zhjw.GetExamSchedules)
+		zhjwGroup.GET("/exam/ics", zhjw.GetExamCalendar)
 		// 选课结果相关接口
 		zhjwGroup.GET("/selection", zhjw.GetSelectionResults)
+		// 选课课程目录 (只读)
+		zhjwGroup.GET("/selection/catalog", zhjw.GetElectiveCatalog)
+		// 无冲突选课方案规划
+		zhjwGroup.POST("/selection/planner", zhjw.PlanTimetable)
 		// 课程表相关接口
 		zhjwGroup.GET("/schedule", zhjw.GetClassSchedules)
+		// 空教室查询
+		zhjwGroup.GET("/free-classroom", zhjw.GetFreeClassrooms)
+		// 公共课表查询 (教师 / 班级 / 教室)
+		zhjwGroup.GET("/timetable/teacher", zhjw.GetTeacherTimetable)
+		zhjwGroup.GET("/timetable/class", zhjw.GetClassTimetable)
+		zhjwGroup.GET("/timetable/classroom", zhjw.GetClassroomTimetable)
+		// 课程、考试、选课时间冲突检测
+		zhjwGroup.GET("/conflicts", zhjw.GetConflicts)
 	}
 
 	// 管理后台接口
@@ -117,11 +177,26 @@ func installAPIRoutes(r *gin.Engine) {
 			authAdmin.POST("/announcements/:id/update", admin.UpdateAnnouncement)
 			authAdmin.POST("/announcements/:id/delete", admin.DeleteAnnouncement)
 
+			// 校历维护
+			authAdmin.GET("/calendar/terms", calendar.GetTerms)
+			authAdmin.POST("/calendar/terms", calendar.SaveTerm)
+			authAdmin.POST("/calendar/terms/delete", calendar.DeleteTerm)
+			authAdmin.GET("/calendar/days", calendar.GetSpecialDays)
+			authAdmin.POST("/calendar/days", calendar.SaveSpecialDay)
+			authAdmin.POST("/calendar/days/delete", calendar.DeleteSpecialDay)
+
 			// 选课推荐管理
 			authAdmin.GET("/course-recommendations", course_recommendation.GetAll)
 			authAdmin.POST("/course-recommendations/review", course_recommendation.Review)
 			authAdmin.POST("/course-recommendations/update", course_recommendation.Update)
 			authAdmin.POST("/course-recommendations/delete", course_recommendation.Delete)
+			authAdmin.GET("/course-recommendations/entities", course_recommendation.GetEntities)
+			authAdmin.POST("/course-recommendations/entities/merge", course_recommendation.MergeEntities)
+			authAdmin.POST("/course-recommendations/entities/rename", course_recommendation.RenameEntity)
+			authAdmin.POST("/course-recommendations/entities/aliases", course_recommendation.AddEntityAlias)
+			authAdmin.POST("/course-recommendations/entities/aliases/delete", course_recommendation.RemoveEntityAlias)
+			authAdmin.GET("/course-recommendations/reports", course_recommendation.GetReports)
+			authAdmin.POST("/course-recommendations/reports/resolve", course_recommendation.ResolveReport)
 		}
 	}
 }
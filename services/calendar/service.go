@@ -0,0 +1,292 @@
+package calendar
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/W1ndys/easy-qfnu-api-go/internal/database"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+var (
+	ErrNotFound        = errors.New("校历记录不存在")
+	ErrInvalidTerm     = errors.New("学期格式错误，应为 2025-2026-1")
+	ErrInvalidDate     = errors.New("日期格式错误，应为 2006-01-02")
+	ErrInvalidWeeks    = errors.New("教学周数应在 1 到 30 之间")
+	ErrInvalidDayType  = errors.New("日期类型只能是 holiday 或 makeup")
+	ErrMissingFollowTo = errors.New("调休补课需要指定按哪一天的课表上课")
+)
+
+const dateLayout = "2006-01-02"
+
+var termPattern = regexp.MustCompile(`^\d{4}-\d{4}-[123]$`)
+
+// ListTerms 获取所有学期，按开始日期倒序
+func ListTerms() ([]model.CalendarTerm, error) {
+	db := database.GetAppDB()
+	if db == nil {
+		return nil, errors.New("数据库连接失败")
+	}
+
+	rows, err := db.Query(`
+		SELECT term, start_date, total_weeks, updated_at
+		FROM calendar_terms
+		ORDER BY start_date DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.CalendarTerm{}
+	for rows.Next() {
+		var t model.CalendarTerm
+		if err := rows.Scan(&t.Term, &t.StartDate, &t.TotalWeeks, &t.UpdatedAt); err != nil {
+			continue
+		}
+		list = append(list, t)
+	}
+	return list, nil
+}
+
+// SaveTerm 新增或更新学期，开始日期会对齐到所在周的周一
+func SaveTerm(req model.CalendarTermRequest) error {
+	term := strings.TrimSpace(req.Term)
+	if !termPattern.MatchString(term) {
+		return ErrInvalidTerm
+	}
+	start, err := time.ParseInLocation(dateLayout, strings.TrimSpace(req.StartDate), time.Local)
+	if err != nil {
+		return ErrInvalidDate
+	}
+	if req.TotalWeeks < 1 || req.TotalWeeks > 30 {
+		return ErrInvalidWeeks
+	}
+	start = MondayOf(start)
+
+	db := database.GetAppDB()
+	if db == nil {
+		return errors.New("数据库连接失败")
+	}
+
+	now := time.Now().Unix()
+	_, err = db.Exec(`
+		INSERT INTO calendar_terms (term, start_date, total_weeks, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(term) DO UPDATE SET start_date = ?, total_weeks = ?, updated_at = ?
+	`, term, start.Format(dateLayout), req.TotalWeeks, now, start.Format(dateLayout), req.TotalWeeks, now)
+	return err
+}
+
+// DeleteTerm 删除学期
+func DeleteTerm(term string) error {
+	return deleteBy("DELETE FROM calendar_terms WHERE term = ?", strings.TrimSpace(term))
+}
+
+// ListSpecialDays 获取所有放假 / 调休补课日期，按日期倒序
+func ListSpecialDays() ([]model.CalendarSpecialDay, error) {
+	db := database.GetAppDB()
+	if db == nil {
+		return nil, errors.New("数据库连接失败")
+	}
+
+	rows, err := db.Query(`
+		SELECT date, type, name, follow_date, updated_at
+		FROM calendar_special_days
+		ORDER BY date DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.CalendarSpecialDay{}
+	for rows.Next() {
+		var d model.CalendarSpecialDay
+		if err := rows.Scan(&d.Date, &d.Type, &d.Name, &d.FollowDate, &d.UpdatedAt); err != nil {
+			continue
+		}
+		list = append(list, d)
+	}
+	return list, nil
+}
+
+// SaveSpecialDay 新增或更新放假 / 调休补课日期
+func SaveSpecialDay(req model.CalendarSpecialDayRequest) error {
+	date := strings.TrimSpace(req.Date)
+	if _, err := time.Parse(dateLayout, date); err != nil {
+		return ErrInvalidDate
+	}
+
+	followDate := strings.TrimSpace(req.FollowDate)
+	switch req.Type {
+	case model.CalendarDayHoliday:
+		followDate = ""
+	case model.CalendarDayMakeup:
+		if followDate == "" {
+			return ErrMissingFollowTo
+		}
+		if _, err := time.Parse(dateLayout, followDate); err != nil {
+			return ErrInvalidDate
+		}
+	default:
+		return ErrInvalidDayType
+	}
+
+	db := database.GetAppDB()
+	if db == nil {
+		return errors.New("数据库连接失败")
+	}
+
+	now := time.Now().Unix()
+	name := strings.TrimSpace(req.Name)
+	_, err := db.Exec(`
+		INSERT INTO calendar_special_days (date, type, name, follow_date, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(date) DO UPDATE SET type = ?, name = ?, follow_date = ?, updated_at = ?
+	`, date, req.Type, name, followDate, now, req.Type, name, followDate, now)
+	return err
+}
+
+// DeleteSpecialDay 删除放假 / 调休补课日期
+func DeleteSpecialDay(date string) error {
+	return deleteBy("DELETE FROM calendar_special_days WHERE date = ?", strings.TrimSpace(date))
+}
+
+// deleteBy 按主键删除一条记录，没有删除任何记录时返回 ErrNotFound
+func deleteBy(query string, key string) error {
+	db := database.GetAppDB()
+	if db == nil {
+		return errors.New("数据库连接失败")
+	}
+
+	result, err := db.Exec(query, key)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// TermStart 获取管理员维护的学期开始日期和教学周数，ok 为 false 表示校历中没有该学期
+func TermStart(term string) (start time.Time, totalWeeks int, ok bool) {
+	db := database.GetAppDB()
+	if db == nil {
+		return time.Time{}, 0, false
+	}
+
+	var startDate string
+	err := db.QueryRow(`SELECT start_date, total_weeks FROM calendar_terms WHERE term = ?`, strings.TrimSpace(term)).
+		Scan(&startDate, &totalWeeks)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	start, err = time.ParseInLocation(dateLayout, startDate, time.Local)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	return start, totalWeeks, true
+}
+
+// Resolve 计算某一天所在的学期、教学周以及当天是否上课
+// 工作日上课，周末不上课；放假日期不上课，调休补课日期按 FollowDate 那天的课表上课
+func Resolve(date time.Time) (*model.CalendarDay, error) {
+	terms, err := ListTerms()
+	if err != nil {
+		return nil, err
+	}
+
+	date = TruncateToDay(date)
+	day := locate(terms, date)
+
+	special, err := findSpecialDay(date.Format(dateLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	day.HasClass = day.InTerm && day.DayOfWeek <= 5
+	if special != nil {
+		day.DayType = special.Type
+		day.DayName = special.Name
+		switch special.Type {
+		case model.CalendarDayHoliday:
+			day.HasClass = false
+		case model.CalendarDayMakeup:
+			day.FollowDate = special.FollowDate
+			follow, err := time.ParseInLocation(dateLayout, special.FollowDate, time.Local)
+			if err == nil {
+				target := locate(terms, follow)
+				day.HasClass = target.InTerm
+				day.ScheduleWeek = target.Week
+				day.ScheduleDayOfWeek = target.DayOfWeek
+			}
+		}
+	}
+	return day, nil
+}
+
+// locate 在学期列表中查找日期所在的学期和教学周
+func locate(terms []model.CalendarTerm, date time.Time) *model.CalendarDay {
+	day := &model.CalendarDay{
+		Date:      date.Format(dateLayout),
+		DayOfWeek: (int(date.Weekday())+6)%7 + 1,
+	}
+	day.ScheduleDayOfWeek = day.DayOfWeek
+
+	for _, t := range terms {
+		start, err := time.ParseInLocation(dateLayout, t.StartDate, time.Local)
+		if err != nil {
+			continue
+		}
+		days := int(math.Round(date.Sub(start).Hours() / 24))
+		if date.Before(start) || days >= t.TotalWeeks*7 {
+			continue
+		}
+		day.Term = t.Term
+		day.InTerm = true
+		day.Week = days/7 + 1
+		day.ScheduleWeek = day.Week
+		break
+	}
+	return day
+}
+
+// findSpecialDay 查询某一天是否为放假 / 调休补课日期，不是时返回 nil
+func findSpecialDay(date string) (*model.CalendarSpecialDay, error) {
+	db := database.GetAppDB()
+	if db == nil {
+		return nil, errors.New("数据库连接失败")
+	}
+
+	var d model.CalendarSpecialDay
+	err := db.QueryRow(`
+		SELECT date, type, name, follow_date, updated_at
+		FROM calendar_special_days WHERE date = ?
+	`, date).Scan(&d.Date, &d.Type, &d.Name, &d.FollowDate, &d.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// TruncateToDay 去掉时分秒，只保留日期
+func TruncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// MondayOf 返回日期所在周的周一
+func MondayOf(t time.Time) time.Time {
+	t = TruncateToDay(t)
+	offset := (int(t.Weekday()) + 6) % 7 // 周一为 0，周日为 6
+	return t.AddDate(0, 0, -offset)
+}
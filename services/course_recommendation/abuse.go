@@ -0,0 +1,191 @@
+package course_recommendation
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/W1ndys/easy-qfnu-api-go/internal/config"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+var (
+	ErrRateLimited      = errors.New("操作过于频繁，请稍后再试")
+	ErrDuplicate        = errors.New("已有相同课程和教师的相似推荐，请勿重复提交")
+	ErrSensitiveContent = errors.New("推荐内容包含不当词语，请修改后重新提交")
+)
+
+const (
+	// rateLimitWindow 提交、投票和举报的频率统计窗口
+	rateLimitWindow = time.Hour
+	// duplicateThreshold 推荐理由相似度达到该值时视为重复提交
+	duplicateThreshold = 0.8
+	// feedbackIPRateLimit 同一 IP 每小时最多投票 / 举报次数，校园网出口 IP 共享，所以放宽
+	feedbackIPRateLimit = 300
+	// feedbackClientRateLimit 同一客户端每小时最多投票 / 举报次数
+	feedbackClientRateLimit = 30
+)
+
+// actionLimiter 按操作类型、IP 和客户端指纹记录窗口内的操作时间
+// 被拒绝的提交 (敏感词、重复) 同样计数，避免反复试探
+var actionLimiter = struct {
+	sync.Mutex
+	hits map[string][]time.Time
+}{hits: make(map[string][]time.Time)}
+
+// allowSubmission 检查并记录一次推荐提交，IP 或指纹任一超过限制时返回 false
+func allowSubmission(client model.RecommendationClient, now time.Time) bool {
+	limit := config.GetRecommendationRateLimit()
+	return allowAction("submit", limit, limit, client, now)
+}
+
+// allowFeedback 检查并记录一次投票或举报
+func allowFeedback(client model.RecommendationClient, now time.Time) bool {
+	return allowAction("feedback", feedbackIPRateLimit, feedbackClientRateLimit, client, now)
+}
+
+// allowAction 滑动窗口限流，ipLimit / clientLimit 为 0 表示对应维度不限制
+func allowAction(action string, ipLimit, clientLimit int, client model.RecommendationClient, now time.Time) bool {
+	type limitKey struct {
+		key   string
+		limit int
+	}
+	var keys []limitKey
+	if client.IP != "" && ipLimit > 0 {
+		keys = append(keys, limitKey{action + ":ip:" + client.IP, ipLimit})
+	}
+	if client.Fingerprint != "" && clientLimit > 0 {
+		keys = append(keys, limitKey{action + ":fp:" + client.Fingerprint, clientLimit})
+	}
+	if len(keys) == 0 {
+		return true
+	}
+
+	actionLimiter.Lock()
+	defer actionLimiter.Unlock()
+
+	cutoff := now.Add(-rateLimitWindow)
+	for _, k := range keys {
+		recent := actionLimiter.hits[k.key][:0]
+		for _, t := range actionLimiter.hits[k.key] {
+			if t.After(cutoff) {
+				recent = append(recent, t)
+			}
+		}
+		actionLimiter.hits[k.key] = recent
+		if len(recent) >= k.limit {
+			return false
+		}
+	}
+
+	for _, k := range keys {
+		actionLimiter.hits[k.key] = append(actionLimiter.hits[k.key], now)
+	}
+
+	// 顺带清理过期的记录，防止长期运行后占用过多内存
+	if len(actionLimiter.hits) > 10000 {
+		for key, times := range actionLimiter.hits {
+			if len(times) == 0 || !times[len(times)-1].After(cutoff) {
+				delete(actionLimiter.hits, key)
+			}
+		}
+	}
+	return true
+}
+
+// contentKeyOf 计算文本的比较键：在别名匹配键的基础上再去掉标点和符号
+// 用于敏感词匹配和相似度计算，"傻 . 瓜" 与 "傻瓜" 视为相同
+func contentKeyOf(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			return -1
+		}
+		return r
+	}, aliasKeyOf(s))
+}
+
+// matchSensitiveWords 返回文本中命中的敏感词
+func matchSensitiveWords(words []string, texts ...string) []string {
+	if len(words) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(texts))
+	for i, text := range texts {
+		keys[i] = contentKeyOf(text)
+	}
+
+	var hits []string
+	for _, word := range words {
+		wordKey := contentKeyOf(word)
+		if wordKey == "" {
+			continue
+		}
+		for _, key := range keys {
+			if strings.Contains(key, wordKey) {
+				hits = append(hits, word)
+				break
+			}
+		}
+	}
+	return hits
+}
+
+// similarity 计算两段文本的相似度 (字符二元组的 Dice 系数)，范围 0-1
+func similarity(a, b string) float64 {
+	ga, gb := bigrams(contentKeyOf(a)), bigrams(contentKeyOf(b))
+	if len(ga) == 0 || len(gb) == 0 {
+		if contentKeyOf(a) == contentKeyOf(b) {
+			return 1
+		}
+		return 0
+	}
+
+	common := 0
+	for g, n := range ga {
+		common += min(n, gb[g])
+	}
+
+	total := 0
+	for _, n := range ga {
+		total += n
+	}
+	for _, n := range gb {
+		total += n
+	}
+	return 2 * float64(common) / float64(total)
+}
+
+// bigrams 统计文本中的字符二元组
+func bigrams(s string) map[string]int {
+	runes := []rune(s)
+	grams := make(map[string]int)
+	for i := 0; i+1 < len(runes); i++ {
+		grams[string(runes[i:i+2])]++
+	}
+	return grams
+}
+
+// checkDuplicate 检查同一课程和教师下是否已有相似的推荐理由
+func checkDuplicate(q sqlExecutor, courseID, teacherID int64, reason string) error {
+	rows, err := q.Query(`
+		SELECT recommendation_reason FROM course_recommendations WHERE course_id = ? AND teacher_id = ?
+	`, courseID, teacherID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var existing string
+		if err := rows.Scan(&existing); err != nil {
+			continue
+		}
+		if similarity(existing, reason) >= duplicateThreshold {
+			return ErrDuplicate
+		}
+	}
+	return rows.Err()
+}
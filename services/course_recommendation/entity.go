@@ -0,0 +1,501 @@
+package course_recommendation
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/W1ndys/easy-qfnu-api-go/internal/database"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+var (
+	ErrEntityNotFound    = errors.New("课程或教师不存在")
+	ErrInvalidEntityName = errors.New("名称不能为空")
+	ErrAliasTaken        = errors.New("该名称已关联到其他课程或教师，请使用合并")
+	ErrAliasIsName       = errors.New("不能删除规范名称本身")
+	ErrMergeIntoSelf     = errors.New("不能将实体合并到自身")
+)
+
+// entityKind 描述一类规范实体 (课程或教师) 对应的表和推荐表中的字段
+type entityKind struct {
+	table      string // 实体表
+	aliasTable string // 别名表
+	idColumn   string // course_recommendations 中的实体 ID 字段
+	nameColumn string // course_recommendations 中的名称字段
+}
+
+var entityKinds = map[string]entityKind{
+	"course":  {table: "course_entities", aliasTable: "course_aliases", idColumn: "course_id", nameColumn: "course_name"},
+	"teacher": {table: "teacher_entities", aliasTable: "teacher_aliases", idColumn: "teacher_id", nameColumn: "teacher_name"},
+}
+
+// sqlExecutor *sql.DB 和 *sql.Tx 的公共方法
+type sqlExecutor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// cleanEntityName 去掉首尾空白并合并连续空白，作为展示用的名称
+func cleanEntityName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// aliasKeyOf 计算别名的匹配键：去掉所有空白，全角字符转半角，字母转大写
+// 这样 "高等数学 A"、"高等数学Ａ"、"高等数学a" 都会匹配到同一个实体
+func aliasKeyOf(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		if r >= 0xFF01 && r <= 0xFF5E {
+			r -= 0xFEE0
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// resolveEntity 根据自由填写的名称找到对应的规范实体，不存在时以该名称新建
+func resolveEntity(q sqlExecutor, kind entityKind, name string) (int64, string, error) {
+	name = cleanEntityName(name)
+	key := aliasKeyOf(name)
+	if key == "" {
+		return 0, "", ErrInvalidEntityName
+	}
+
+	var id int64
+	var canonical string
+	err := q.QueryRow(`
+		SELECT e.id, e.name FROM `+kind.aliasTable+` a
+		JOIN `+kind.table+` e ON e.id = a.entity_id
+		WHERE a.alias_key = ?
+	`, key).Scan(&id, &canonical)
+	if err == nil {
+		return id, canonical, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, "", err
+	}
+
+	result, err := q.Exec(`INSERT INTO `+kind.table+` (name, created_at) VALUES (?, ?)`, name, time.Now().Unix())
+	if err != nil {
+		return 0, "", err
+	}
+	id, err = result.LastInsertId()
+	if err != nil {
+		return 0, "", err
+	}
+	if _, err := q.Exec(`INSERT INTO `+kind.aliasTable+` (alias_key, alias, entity_id) VALUES (?, ?, ?)`, key, name, id); err != nil {
+		return 0, "", err
+	}
+	return id, name, nil
+}
+
+// linkedNames 新提交或修改的推荐关联到的课程和教师
+type linkedNames struct {
+	courseID    int64
+	courseName  string
+	teacherID   int64
+	teacherName string
+}
+
+// resolveNames 同时解析课程和教师名称
+func resolveNames(q sqlExecutor, courseName, teacherName string) (linkedNames, error) {
+	var l linkedNames
+	var err error
+	if l.courseID, l.courseName, err = resolveEntity(q, entityKinds["course"], courseName); err != nil {
+		return l, err
+	}
+	if l.teacherID, l.teacherName, err = resolveEntity(q, entityKinds["teacher"], teacherName); err != nil {
+		return l, err
+	}
+	return l, nil
+}
+
+// relinkRecommendation 重新为推荐关联课程和教师，被拒绝的推荐恢复为其他状态时调用
+func relinkRecommendation(q sqlExecutor, id int64) error {
+	var courseName, teacherName string
+	err := q.QueryRow(`SELECT course_name, teacher_name FROM course_recommendations WHERE id = ?`, id).Scan(&courseName, &teacherName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	l, err := resolveNames(q, courseName, teacherName)
+	if err != nil {
+		return err
+	}
+	if _, err := q.Exec(`
+		UPDATE course_recommendations SET course_id = ?, course_name = ?, teacher_id = ?, teacher_name = ? WHERE id = ?
+	`, l.courseID, l.courseName, l.teacherID, l.teacherName, id); err != nil {
+		return err
+	}
+	return indexRecommendations(q, "id = ?", id)
+}
+
+// unlinkRecommendation 解除推荐与课程和教师的关联并清理不再被引用的实体，推荐被拒绝时调用
+// 这样被拒绝的垃圾投稿不会在后台的课程 / 教师列表中留下实体
+func unlinkRecommendation(q sqlExecutor, id int64) error {
+	var courseID, teacherID int64
+	err := q.QueryRow(`SELECT course_id, teacher_id FROM course_recommendations WHERE id = ?`, id).Scan(&courseID, &teacherID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.Exec(`UPDATE course_recommendations SET course_id = 0, teacher_id = 0 WHERE id = ?`, id); err != nil {
+		return err
+	}
+	if err := pruneEntities(q, courseID, teacherID); err != nil {
+		return err
+	}
+	return indexRecommendations(q, "id = ?", id)
+}
+
+// pruneEntities 删除没有推荐引用的课程和教师实体，ID 为 0 时跳过
+func pruneEntities(q sqlExecutor, courseID, teacherID int64) error {
+	if err := pruneEntity(q, entityKinds["course"], courseID); err != nil {
+		return err
+	}
+	return pruneEntity(q, entityKinds["teacher"], teacherID)
+}
+
+// pruneEntity 删除没有推荐引用的实体
+// 只清理仅有规范名称一个别名的实体 (即由投稿自动创建的)，管理员添加过别名或合并过的实体即使暂时没有推荐也保留
+func pruneEntity(q sqlExecutor, kind entityKind, id int64) error {
+	if id == 0 {
+		return nil
+	}
+
+	var refs, aliases int
+	if err := q.QueryRow(`SELECT COUNT(*) FROM course_recommendations WHERE `+kind.idColumn+` = ?`, id).Scan(&refs); err != nil {
+		return err
+	}
+	if err := q.QueryRow(`SELECT COUNT(*) FROM `+kind.aliasTable+` WHERE entity_id = ?`, id).Scan(&aliases); err != nil {
+		return err
+	}
+	if refs > 0 || aliases > 1 {
+		return nil
+	}
+
+	if _, err := q.Exec(`DELETE FROM `+kind.aliasTable+` WHERE entity_id = ?`, id); err != nil {
+		return err
+	}
+	_, err := q.Exec(`DELETE FROM `+kind.table+` WHERE id = ?`, id)
+	return err
+}
+
+// LinkUnlinked 为尚未关联规范实体的历史推荐建立关联，启动时调用一次
+// 被拒绝的推荐不关联实体，跳过
+func LinkUnlinked() {
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return
+	}
+
+	rows, err := db.Query(`SELECT id, course_name, teacher_name FROM course_recommendations WHERE (course_id = 0 OR teacher_id = 0) AND status != 'rejected'`)
+	if err != nil {
+		slog.Warn("查询未关联的课程推荐失败", "error", err)
+		return
+	}
+	type pending struct {
+		id                      int64
+		courseName, teacherName string
+	}
+	var list []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.courseName, &p.teacherName); err != nil {
+			continue
+		}
+		list = append(list, p)
+	}
+	rows.Close()
+	if len(list) == 0 {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		slog.Warn("关联课程推荐失败", "error", err)
+		return
+	}
+	defer tx.Rollback()
+
+	for _, p := range list {
+		l, err := resolveNames(tx, p.courseName, p.teacherName)
+		if err != nil {
+			slog.Warn("关联课程推荐失败", "id", p.id, "error", err)
+			continue
+		}
+		if _, err := tx.Exec(`
+			UPDATE course_recommendations SET course_id = ?, course_name = ?, teacher_id = ?, teacher_name = ? WHERE id = ?
+		`, l.courseID, l.courseName, l.teacherID, l.teacherName, p.id); err != nil {
+			slog.Warn("关联课程推荐失败", "id", p.id, "error", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.Warn("关联课程推荐失败", "error", err)
+		return
+	}
+	slog.Info("已为历史课程推荐关联规范课程和教师", "count", len(list))
+}
+
+// ListEntities 获取规范课程或教师列表，keyword 按名称或别名过滤
+func ListEntities(kindName, keyword string) ([]model.RecommendationEntity, error) {
+	kind, ok := entityKinds[kindName]
+	if !ok {
+		return nil, ErrEntityNotFound
+	}
+
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return nil, errors.New("数据库连接失败")
+	}
+
+	pattern := "%" + strings.TrimSpace(keyword) + "%"
+	rows, err := db.Query(`
+		SELECT e.id, e.name, e.created_at,
+			(SELECT COUNT(*) FROM course_recommendations r WHERE r.`+kind.idColumn+` = e.id)
+		FROM `+kind.table+` e
+		WHERE e.name LIKE ? OR e.id IN (SELECT entity_id FROM `+kind.aliasTable+` WHERE alias LIKE ?)
+		ORDER BY e.name
+	`, pattern, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []model.RecommendationEntity{}
+	index := make(map[int64]int)
+	for rows.Next() {
+		var e model.RecommendationEntity
+		if err := rows.Scan(&e.ID, &e.Name, &e.CreatedAt, &e.RecommendationCount); err != nil {
+			continue
+		}
+		e.Aliases = []string{}
+		index[e.ID] = len(list)
+		list = append(list, e)
+	}
+	rows.Close()
+
+	aliasRows, err := db.Query(`SELECT entity_id, alias FROM ` + kind.aliasTable + ` ORDER BY alias`)
+	if err != nil {
+		return nil, err
+	}
+	defer aliasRows.Close()
+	for aliasRows.Next() {
+		var id int64
+		var alias string
+		if err := aliasRows.Scan(&id, &alias); err != nil {
+			continue
+		}
+		if i, ok := index[id]; ok {
+			list[i].Aliases = append(list[i].Aliases, alias)
+		}
+	}
+
+	return list, nil
+}
+
+// MergeEntities 将若干重复实体合并到目标实体
+// 被合并实体的别名和关联推荐全部转到目标实体下，推荐中的名称同步改为目标实体的规范名称
+func MergeEntities(req model.RecommendationEntityMergeRequest) error {
+	kind, ok := entityKinds[req.Kind]
+	if !ok {
+		return ErrEntityNotFound
+	}
+
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return errors.New("数据库连接失败")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	targetName, err := entityName(tx, kind, req.TargetID)
+	if err != nil {
+		return err
+	}
+
+	for _, sourceID := range req.SourceIDs {
+		if sourceID == req.TargetID {
+			return ErrMergeIntoSelf
+		}
+		if _, err := entityName(tx, kind, sourceID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`UPDATE `+kind.aliasTable+` SET entity_id = ? WHERE entity_id = ?`, req.TargetID, sourceID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`
+			UPDATE course_recommendations SET `+kind.idColumn+` = ?, `+kind.nameColumn+` = ? WHERE `+kind.idColumn+` = ?
+		`, req.TargetID, targetName, sourceID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM `+kind.table+` WHERE id = ?`, sourceID); err != nil {
+			return err
+		}
+	}
+
+	if err := indexRecommendations(tx, kind.idColumn+" = ?", req.TargetID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RenameEntity 修改实体的规范名称，旧名称保留为别名，关联推荐中的名称同步更新
+func RenameEntity(req model.RecommendationEntityRenameRequest) error {
+	kind, ok := entityKinds[req.Kind]
+	if !ok {
+		return ErrEntityNotFound
+	}
+	name := cleanEntityName(req.Name)
+	if name == "" {
+		return ErrInvalidEntityName
+	}
+
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return errors.New("数据库连接失败")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := entityName(tx, kind, req.ID); err != nil {
+		return err
+	}
+	if err := addAlias(tx, kind, req.ID, name); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE `+kind.table+` SET name = ? WHERE id = ?`, name, req.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`
+		UPDATE course_recommendations SET `+kind.nameColumn+` = ? WHERE `+kind.idColumn+` = ?
+	`, name, req.ID); err != nil {
+		return err
+	}
+
+	if err := indexRecommendations(tx, kind.idColumn+" = ?", req.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AddAlias 为实体添加别名，之后以该名称提交的推荐会自动关联到此实体
+func AddAlias(req model.RecommendationEntityAliasRequest) error {
+	kind, ok := entityKinds[req.Kind]
+	if !ok {
+		return ErrEntityNotFound
+	}
+
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return errors.New("数据库连接失败")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := entityName(tx, kind, req.ID); err != nil {
+		return err
+	}
+	if err := addAlias(tx, kind, req.ID, req.Alias); err != nil {
+		return err
+	}
+
+	if err := indexRecommendations(tx, kind.idColumn+" = ?", req.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RemoveAlias 删除实体的别名，规范名称本身不能删除
+func RemoveAlias(req model.RecommendationEntityAliasRequest) error {
+	kind, ok := entityKinds[req.Kind]
+	if !ok {
+		return ErrEntityNotFound
+	}
+	key := aliasKeyOf(req.Alias)
+
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return errors.New("数据库连接失败")
+	}
+
+	name, err := entityName(db, kind, req.ID)
+	if err != nil {
+		return err
+	}
+	if aliasKeyOf(name) == key {
+		return ErrAliasIsName
+	}
+
+	result, err := db.Exec(`DELETE FROM `+kind.aliasTable+` WHERE alias_key = ? AND entity_id = ?`, key, req.ID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrEntityNotFound
+	}
+	return indexRecommendations(db, kind.idColumn+" = ?", req.ID)
+}
+
+// addAlias 添加别名，别名已属于其他实体时返回 ErrAliasTaken，已属于本实体时忽略
+func addAlias(q sqlExecutor, kind entityKind, id int64, alias string) error {
+	alias = cleanEntityName(alias)
+	key := aliasKeyOf(alias)
+	if key == "" {
+		return ErrInvalidEntityName
+	}
+
+	var owner int64
+	err := q.QueryRow(`SELECT entity_id FROM `+kind.aliasTable+` WHERE alias_key = ?`, key).Scan(&owner)
+	switch {
+	case err == nil && owner == id:
+		return nil
+	case err == nil:
+		return ErrAliasTaken
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	_, err = q.Exec(`INSERT INTO `+kind.aliasTable+` (alias_key, alias, entity_id) VALUES (?, ?, ?)`, key, alias, id)
+	return err
+}
+
+// entityName 获取实体的规范名称，不存在时返回 ErrEntityNotFound
+func entityName(q sqlExecutor, kind entityKind, id int64) (string, error) {
+	var name string
+	err := q.QueryRow(`SELECT name FROM `+kind.table+` WHERE id = ?`, id).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrEntityNotFound
+	}
+	return name, err
+}
@@ -0,0 +1,263 @@
+package course_recommendation
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/W1ndys/easy-qfnu-api-go/common/notify"
+	"github.com/W1ndys/easy-qfnu-api-go/internal/database"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+var (
+	ErrInvalidReportReason = errors.New("请填写举报原因")
+	ErrAlreadyReported     = errors.New("你已举报过该推荐，请等待管理员处理")
+	ErrReportNotFound      = errors.New("举报记录不存在")
+	ErrReportResolved      = errors.New("该举报已处理")
+)
+
+// feedbackPerIPLimit 同一 IP 对同一条推荐最多计入的有用票数 / 待处理举报数
+// 客户端标识可以随意更换，按 IP 再加一道上限；校园网出口 IP 共享，所以不是 1
+const feedbackPerIPLimit = 3
+
+// 举报状态
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// MarkHelpful 将一条公开的推荐标记为有用，同一客户端 (IP 加客户端标识) 重复标记不会重复计数
+// 被忽略的重复标记不占用频率限制的次数
+func MarkHelpful(recommendationID int64, client model.RecommendationClient) (*model.RecommendationHelpfulResponse, error) {
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return nil, errors.New("数据库连接失败")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := ensureVisible(tx, recommendationID); err != nil {
+		return nil, err
+	}
+
+	duplicate, err := isDuplicateFeedback(tx, "recommendation_votes", "", recommendationID, client)
+	if err != nil {
+		return nil, err
+	}
+	if !duplicate {
+		if !allowFeedback(client, time.Now()) {
+			return nil, ErrRateLimited
+		}
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO recommendation_votes (recommendation_id, client_key, ip, created_at) VALUES (?, ?, ?, ?)
+		`, recommendationID, clientKeyOf(client), client.IP, time.Now().Unix()); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(`UPDATE course_recommendations SET helpful_count = helpful_count + 1 WHERE id = ?`, recommendationID); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &model.RecommendationHelpfulResponse{AlreadyVoted: duplicate}
+	if err := tx.QueryRow(`SELECT helpful_count FROM course_recommendations WHERE id = ?`, recommendationID).Scan(&resp.HelpfulCount); err != nil {
+		return nil, err
+	}
+	return resp, tx.Commit()
+}
+
+// Report 举报一条公开的推荐，同一客户端 (IP 加客户端标识) 对同一推荐只能有一条待处理的举报
+func Report(req model.RecommendationReportRequest, client model.RecommendationClient) error {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return ErrInvalidReportReason
+	}
+
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return errors.New("数据库连接失败")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := ensureVisible(tx, req.RecommendationID); err != nil {
+		return err
+	}
+
+	duplicate, err := isDuplicateFeedback(tx, "recommendation_reports", "status = '"+ReportStatusOpen+"'", req.RecommendationID, client)
+	if err != nil {
+		return err
+	}
+	if duplicate {
+		return ErrAlreadyReported
+	}
+	if !allowFeedback(client, time.Now()) {
+		return ErrRateLimited
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO recommendation_reports (recommendation_id, client_key, ip, reason, status, created_at) VALUES (?, ?, ?, ?, ?, ?)
+	`, req.RecommendationID, clientKeyOf(client), client.IP, reason, ReportStatusOpen, time.Now().Unix()); err != nil {
+		return err
+	}
+
+	var courseName, teacherName string
+	if err := tx.QueryRow(`SELECT course_name, teacher_name FROM course_recommendations WHERE id = ?`, req.RecommendationID).Scan(&courseName, &teacherName); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	notify.NotifyCustom("选课推荐被举报", fmt.Sprintf(`**🚩 有用户举报了选课推荐，请及时处理**
+
+- **推荐 ID**: %d
+- **课程名称**: %s
+- **授课教师**: %s
+- **举报原因**: %s`, req.RecommendationID, courseName, teacherName, reason), "orange")
+	return nil
+}
+
+// clientKeyOf 投票和举报的去重键：IP 加客户端标识
+// 只用客户端标识时更换 X-Client-ID 即可重复投票，只用 IP 时同一出口 IP 下的同学会互相影响
+func clientKeyOf(client model.RecommendationClient) string {
+	return client.IP + "|" + client.Fingerprint
+}
+
+// isDuplicateFeedback 判断本次投票或举报是否重复：同一客户端已有记录，或同一 IP 的记录已达到上限
+// table 为 recommendation_votes 或 recommendation_reports，extra 为额外的过滤条件 (可为空)
+func isDuplicateFeedback(q sqlExecutor, table, extra string, recommendationID int64, client model.RecommendationClient) (bool, error) {
+	where := "recommendation_id = ?"
+	if extra != "" {
+		where += " AND " + extra
+	}
+
+	var sameClient, sameIP int
+	if err := q.QueryRow(`
+		SELECT COUNT(CASE WHEN client_key = ? THEN 1 END), COUNT(CASE WHEN ip = ? THEN 1 END) FROM `+table+` WHERE `+where,
+		clientKeyOf(client), client.IP, recommendationID).Scan(&sameClient, &sameIP); err != nil {
+		return false, err
+	}
+	return sameClient > 0 || (client.IP != "" && sameIP >= feedbackPerIPLimit), nil
+}
+
+// ensureVisible 检查推荐是否存在且已公开，只有公开的推荐才能投票和举报
+func ensureVisible(q sqlExecutor, recommendationID int64) error {
+	var visible bool
+	err := q.QueryRow(`SELECT is_visible FROM course_recommendations WHERE id = ?`, recommendationID).Scan(&visible)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !visible) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// GetReports 获取举报列表（管理员用），status 为空或 all 时返回全部
+func GetReports(page, pageSize int, status string) ([]model.RecommendationReport, int64, error) {
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return nil, 0, errors.New("数据库连接失败")
+	}
+
+	whereSQL := ""
+	var args []any
+	if status != "" && status != "all" {
+		whereSQL = "WHERE p.status = ?"
+		args = append(args, status)
+	}
+
+	var total int64
+	if err := db.QueryRow("SELECT COUNT(*) FROM recommendation_reports p "+whereSQL, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	rows, err := db.Query(`
+		SELECT p.id, p.recommendation_id, p.reason, p.status, p.resolution, p.created_at, p.resolved_at,
+			COALESCE(r.course_name, ''), COALESCE(r.teacher_name, ''), COALESCE(r.recommendation_reason, ''), COALESCE(r.is_visible, 0)
+		FROM recommendation_reports p
+		LEFT JOIN course_recommendations r ON r.id = p.recommendation_id
+		`+whereSQL+`
+		ORDER BY p.created_at DESC
+		LIMIT ? OFFSET ?
+	`, append(args, pageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	list := []model.RecommendationReport{}
+	for rows.Next() {
+		var p model.RecommendationReport
+		if err := rows.Scan(&p.ID, &p.RecommendationID, &p.Reason, &p.Status, &p.Resolution, &p.CreatedAt, &p.ResolvedAt,
+			&p.CourseName, &p.TeacherName, &p.RecommendationReason, &p.IsVisible); err != nil {
+			continue
+		}
+		list = append(list, p)
+	}
+	return list, total, nil
+}
+
+// ResolveReport 处理举报（管理员用）
+// dismiss 只驳回当前举报；hide 隐藏被举报的推荐，delete 删除被举报的推荐，两者都会一并处理该推荐的其他待处理举报
+func ResolveReport(req model.RecommendationReportResolveRequest, reviewer string) error {
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return errors.New("数据库连接失败")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var recommendationID int64
+	var status string
+	err = tx.QueryRow(`SELECT recommendation_id, status FROM recommendation_reports WHERE id = ?`, req.ReportID).Scan(&recommendationID, &status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrReportNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != ReportStatusOpen {
+		return ErrReportResolved
+	}
+
+	now := time.Now().Unix()
+	switch req.Action {
+	case "dismiss":
+		if _, err := tx.Exec(`
+			UPDATE recommendation_reports SET status = ?, resolution = ?, resolved_at = ? WHERE id = ?
+		`, ReportStatusDismissed, req.Resolution, now, req.ReportID); err != nil {
+			return err
+		}
+		return tx.Commit()
+	case "hide":
+		if err := setStatus(tx, recommendationID, StatusHidden, reviewer, req.Resolution); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	case "delete":
+		if err := deleteRecommendation(tx, recommendationID); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE recommendation_reports SET status = ?, resolution = ?, resolved_at = ? WHERE recommendation_id = ? AND status = ?
+	`, ReportStatusResolved, req.Resolution, now, recommendationID, ReportStatusOpen); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,145 @@
+package course_recommendation
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/W1ndys/easy-qfnu-api-go/internal/database"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+var (
+	ErrRejectReasonRequired = errors.New("拒绝推荐时请填写拒绝原因")
+	ErrInvalidStatusToken   = errors.New("查询凭证无效")
+)
+
+// 推荐审核状态，只有 approved 的推荐对外公开
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+	StatusHidden   = "hidden"
+)
+
+// defaultReviewer 取不到当前管理员时记录的名称，后台目前只有一个管理员账号
+const defaultReviewer = "admin"
+
+// Review 审核课程推荐，记录审核人、审核时间和拒绝原因，reviewer 为当前登录的管理员
+// 未指定 status 时兼容旧版参数：is_visible 为 true 视为通过，为 false 视为隐藏
+func Review(req model.CourseRecommendationReviewRequest, reviewer string) error {
+	status := req.Status
+	if status == "" {
+		status = StatusHidden
+		if req.IsVisible {
+			status = StatusApproved
+		}
+	}
+
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return errors.New("数据库连接失败")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setStatus(tx, req.RecommendationID, status, reviewer, req.RejectReason); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// setStatus 修改推荐的审核状态并同步 is_visible
+// 拒绝时必须填写原因；隐藏时原因可选；改为待审核时清空审核记录
+// 被拒绝的推荐解除与课程和教师的关联，从拒绝恢复为其他状态时重新关联
+func setStatus(q sqlExecutor, recommendationID int64, status, reviewer, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if status == StatusRejected && reason == "" {
+		return ErrRejectReasonRequired
+	}
+
+	var current string
+	err := q.QueryRow(`SELECT status FROM course_recommendations WHERE id = ?`, recommendationID).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status == StatusApproved {
+		reason = ""
+	}
+
+	reviewer = strings.TrimSpace(reviewer)
+	if reviewer == "" {
+		reviewer = defaultReviewer
+	}
+	reviewedAt := time.Now().Unix()
+	if status == StatusPending {
+		reviewer, reviewedAt, reason = "", 0, ""
+	}
+
+	if _, err := q.Exec(`
+		UPDATE course_recommendations SET status = ?, is_visible = ?, reviewer = ?, reviewed_at = ?, reject_reason = ? WHERE id = ?
+	`, status, status == StatusApproved, reviewer, reviewedAt, reason, recommendationID); err != nil {
+		return err
+	}
+
+	switch {
+	case status == StatusRejected && current != StatusRejected:
+		return unlinkRecommendation(q, recommendationID)
+	case status != StatusRejected && current == StatusRejected:
+		return relinkRecommendation(q, recommendationID)
+	}
+	return nil
+}
+
+// GetStatus 根据提交时返回的凭证查询投稿的审核状态
+func GetStatus(token string) (*model.CourseRecommendationStatusResponse, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrInvalidStatusToken
+	}
+
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return nil, errors.New("数据库连接失败")
+	}
+
+	var resp model.CourseRecommendationStatusResponse
+	err := db.QueryRow(`
+		SELECT course_name, teacher_name, recommendation_time, status, reviewed_at, reject_reason
+		FROM course_recommendations WHERE status_token = ?
+	`, hashStatusToken(token)).Scan(&resp.CourseName, &resp.TeacherName, &resp.RecommendationTime, &resp.Status, &resp.ReviewedAt, &resp.RejectReason)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidStatusToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// newStatusToken 生成投稿状态查询凭证，返回明文凭证和入库用的哈希
+func newStatusToken() (string, string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(buf)
+	return token, hashStatusToken(token), nil
+}
+
+// hashStatusToken 数据库中只保存凭证的哈希，数据泄露时无法据此冒查
+func hashStatusToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,71 @@
+package course_recommendation
+
+import (
+	"math"
+
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// summarizeRatings 按课程以及课程 + 教师汇总评分，保持在列表中首次出现的顺序
+func summarizeRatings(list []model.CourseRecommendationPublic) (courses []model.CourseRatingSummary, courseTeachers []model.CourseRatingSummary) {
+	courseIndex := make(map[string]int)
+	pairIndex := make(map[string]int)
+	courses = []model.CourseRatingSummary{}
+	courseTeachers = []model.CourseRatingSummary{}
+
+	for _, r := range list {
+		i, ok := courseIndex[r.CourseName]
+		if !ok {
+			i = len(courses)
+			courseIndex[r.CourseName] = i
+			courses = append(courses, model.CourseRatingSummary{CourseName: r.CourseName})
+		}
+		addRatings(&courses[i], r.Ratings)
+
+		key := r.CourseName + "\x00" + r.TeacherName
+		j, ok := pairIndex[key]
+		if !ok {
+			j = len(courseTeachers)
+			pairIndex[key] = j
+			courseTeachers = append(courseTeachers, model.CourseRatingSummary{CourseName: r.CourseName, TeacherName: r.TeacherName})
+		}
+		addRatings(&courseTeachers[j], r.Ratings)
+	}
+
+	for i := range courses {
+		finishRatings(&courses[i])
+	}
+	for i := range courseTeachers {
+		finishRatings(&courseTeachers[i])
+	}
+	return courses, courseTeachers
+}
+
+// addRatings 累加一条推荐的评分，平均分暂存总分，由 finishRatings 统一计算
+func addRatings(summary *model.CourseRatingSummary, ratings model.CourseRatings) {
+	summary.RecommendationCount++
+	addRating(&summary.Difficulty, ratings.Difficulty)
+	addRating(&summary.Workload, ratings.Workload)
+	addRating(&summary.GradingLeniency, ratings.GradingLeniency)
+	addRating(&summary.AttendanceStrictness, ratings.AttendanceStrictness)
+	addRating(&summary.Overall, ratings.Overall)
+}
+
+func addRating(agg *model.RatingAggregate, score int) {
+	if score < 1 || score > 5 {
+		return // 未评分
+	}
+	agg.Count++
+	agg.Average += float64(score)
+	agg.Distribution[score-1]++
+}
+
+func finishRatings(summary *model.CourseRatingSummary) {
+	for _, agg := range []*model.RatingAggregate{
+		&summary.Difficulty, &summary.Workload, &summary.GradingLeniency, &summary.AttendanceStrictness, &summary.Overall,
+	} {
+		if agg.Count > 0 {
+			agg.Average = math.Round(agg.Average/float64(agg.Count)*100) / 100
+		}
+	}
+}
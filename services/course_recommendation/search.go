@@ -0,0 +1,177 @@
+package course_recommendation
+
+import (
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"github.com/W1ndys/easy-qfnu-api-go/internal/database"
+	"github.com/mozillazg/go-pinyin"
+)
+
+// searchColumnWeights 全文索引各列在 bm25 排序中的权重：课程名称、教师姓名、推荐理由、教师拼音
+const searchColumnWeights = "10.0, 8.0, 1.0, 6.0"
+
+// segmentForSearch 在每个汉字两侧加空格，使 FTS5 默认分词器按单字切分中文
+// 这样任意长度的中文片段都能通过短语查询匹配，而不只是整词
+func segmentForSearch(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			b.WriteRune(' ')
+			b.WriteRune(r)
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// pinyinTokens 生成姓名的拼音索引词：全拼连写、逐字拼音 (含多音字) 和首字母
+// 如 "张三" -> "zhangsan zhang san zs"，这样 "zhangsan"、"zhang"、"zs" 都能找到张三
+func pinyinTokens(names ...string) string {
+	normal := pinyin.NewArgs()
+	heteronym := pinyin.NewArgs()
+	heteronym.Heteronym = true
+	initials := pinyin.NewArgs()
+	initials.Style = pinyin.FirstLetter
+
+	var tokens []string
+	for _, name := range names {
+		full := pinyin.LazyPinyin(name, normal)
+		if len(full) == 0 {
+			continue
+		}
+		tokens = append(tokens, strings.Join(full, ""))
+		for _, readings := range pinyin.Pinyin(name, heteronym) {
+			tokens = append(tokens, readings...)
+		}
+		tokens = append(tokens, strings.Join(pinyin.LazyPinyin(name, initials), ""))
+	}
+	return strings.Join(tokens, " ")
+}
+
+// buildMatchQuery 将用户输入的关键词转换为 FTS5 查询
+// 空白分隔的每一段作为一个前缀短语，多段之间为 AND 关系，关键词中没有可检索内容时返回空串
+func buildMatchQuery(keyword string) string {
+	var terms []string
+	for _, field := range strings.Fields(keyword) {
+		field = strings.ReplaceAll(field, `"`, "")
+		if !strings.ContainsFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			continue
+		}
+		terms = append(terms, `"`+strings.TrimSpace(segmentForSearch(field))+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
+// searchDocument 一条推荐在全文索引中的内容
+type searchDocument struct {
+	id                      int64
+	courseID, teacherID     int64
+	courseName, teacherName string
+	reason                  string
+}
+
+// indexRecommendations 重建满足条件的推荐的全文索引，where 为 course_recommendations 上的过滤条件
+// 课程和教师的别名一并写入索引，所以按别名也能搜索到
+func indexRecommendations(q sqlExecutor, where string, args ...any) error {
+	rows, err := q.Query(`
+		SELECT id, course_id, teacher_id, course_name, teacher_name, recommendation_reason
+		FROM course_recommendations
+		WHERE `+where, args...)
+	if err != nil {
+		return err
+	}
+	var docs []searchDocument
+	for rows.Next() {
+		var d searchDocument
+		if err := rows.Scan(&d.id, &d.courseID, &d.teacherID, &d.courseName, &d.teacherName, &d.reason); err != nil {
+			continue
+		}
+		docs = append(docs, d)
+	}
+	rows.Close()
+
+	courseAliases := make(map[int64][]string)
+	teacherAliases := make(map[int64][]string)
+	for _, d := range docs {
+		courseNames, err := cachedAliases(q, entityKinds["course"], d.courseID, d.courseName, courseAliases)
+		if err != nil {
+			return err
+		}
+		teacherNames, err := cachedAliases(q, entityKinds["teacher"], d.teacherID, d.teacherName, teacherAliases)
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.Exec(`DELETE FROM course_recommendations_fts WHERE rowid = ?`, d.id); err != nil {
+			return err
+		}
+		if _, err := q.Exec(`
+			INSERT INTO course_recommendations_fts (rowid, course_name, teacher_name, recommendation_reason, teacher_pinyin)
+			VALUES (?, ?, ?, ?, ?)
+		`, d.id, segmentForSearch(strings.Join(courseNames, " ")), segmentForSearch(strings.Join(teacherNames, " ")),
+			segmentForSearch(d.reason), pinyinTokens(teacherNames...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cachedAliases 获取实体的规范名称及所有别名，未关联实体时只返回推荐中填写的名称
+func cachedAliases(q sqlExecutor, kind entityKind, id int64, name string, cache map[int64][]string) ([]string, error) {
+	if id == 0 {
+		return []string{name}, nil
+	}
+	if names, ok := cache[id]; ok {
+		return names, nil
+	}
+
+	rows, err := q.Query(`SELECT alias FROM `+kind.aliasTable+` WHERE entity_id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []string{name}
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			continue
+		}
+		if alias != name {
+			names = append(names, alias)
+		}
+	}
+	cache[id] = names
+	return names, nil
+}
+
+// RebuildSearchIndex 重建全部课程推荐的全文索引，启动时调用一次
+func RebuildSearchIndex() {
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		slog.Warn("重建课程推荐索引失败", "error", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM course_recommendations_fts`); err != nil {
+		slog.Warn("重建课程推荐索引失败", "error", err)
+		return
+	}
+	if err := indexRecommendations(tx, "1 = 1"); err != nil {
+		slog.Warn("重建课程推荐索引失败", "error", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		slog.Warn("重建课程推荐索引失败", "error", err)
+	}
+}
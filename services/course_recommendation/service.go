@@ -1,10 +1,13 @@
 package course_recommendation
 
 import (
+	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/W1ndys/easy-qfnu-api-go/common/notify"
+	"github.com/W1ndys/easy-qfnu-api-go/internal/config"
 	"github.com/W1ndys/easy-qfnu-api-go/internal/database"
 	"github.com/W1ndys/easy-qfnu-api-go/model"
 )
@@ -13,45 +16,172 @@ var (
 	ErrNotFound = errors.New("推荐记录不存在")
 )
 
-// Query 根据关键词查询可见的课程推荐（匹配课程名称或教师姓名）
-func Query(keyword string) ([]model.CourseRecommendationPublic, error) {
+// ratingColumns 多维度评分字段，顺序与 model.CourseRatings 一致
+const ratingColumns = "rating_difficulty, rating_workload, rating_grading, rating_attendance, rating_overall"
+
+// Query 全文检索可见的课程推荐，按相关度排序并分页，同时汇总所有符合条件的推荐的评分
+// 关键词匹配课程名称、教师姓名 (含拼音及首字母) 和推荐理由，课程和教师的别名同样可以匹配
+func Query(req model.CourseRecommendationQueryRequest) (*model.CourseRecommendationQueryResponse, error) {
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	resp := &model.CourseRecommendationQueryResponse{
+		List:           []model.CourseRecommendationPublic{},
+		Page:           page,
+		PageSize:       pageSize,
+		Courses:        []model.CourseRatingSummary{},
+		CourseTeachers: []model.CourseRatingSummary{},
+	}
+
+	match := buildMatchQuery(req.Keyword)
+	if match == "" {
+		return resp, nil
+	}
+
 	db := database.GetCourseRecDB()
 	if db == nil {
 		return nil, errors.New("数据库连接失败")
 	}
 
-	pattern := "%" + keyword + "%"
+	// 构建查询条件
+	whereSQL := "course_recommendations_fts MATCH ? AND r.is_visible = 1"
+	args := []any{match}
+	if req.Campus != "" {
+		whereSQL += " AND r.campus = ?"
+		args = append(args, req.Campus)
+	}
+	if req.RecommendationYear != "" {
+		whereSQL += " AND r.recommendation_year = ?"
+		args = append(args, req.RecommendationYear)
+	}
+	if req.MinRating > 0 {
+		whereSQL += " AND r.rating_overall >= ?"
+		args = append(args, req.MinRating)
+	}
+	fromSQL := `
+		FROM course_recommendations_fts
+		JOIN course_recommendations r ON r.id = course_recommendations_fts.rowid
+		WHERE ` + whereSQL
+
+	// 1. 汇总所有符合条件的推荐的评分
 	rows, err := db.Query(`
-		SELECT course_name, teacher_name, recommendation_reason, recommender_nickname, recommendation_time, campus, recommendation_year
-		FROM course_recommendations
-		WHERE is_visible = 1 AND (course_name LIKE ? OR teacher_name LIKE ?)
-		ORDER BY recommendation_time DESC
-	`, pattern, pattern)
+		SELECT r.course_name, r.teacher_name, r.rating_difficulty, r.rating_workload, r.rating_grading, r.rating_attendance, r.rating_overall
+		`+fromSQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	var matched []model.CourseRecommendationPublic
+	for rows.Next() {
+		var r model.CourseRecommendationPublic
+		if err := rows.Scan(&r.CourseName, &r.TeacherName,
+			&r.Ratings.Difficulty, &r.Ratings.Workload, &r.Ratings.GradingLeniency, &r.Ratings.AttendanceStrictness, &r.Ratings.Overall); err != nil {
+			continue
+		}
+		matched = append(matched, r)
+	}
+	rows.Close()
+
+	resp.Total = int64(len(matched))
+	if resp.Total == 0 {
+		return resp, nil
+	}
+	resp.Courses, resp.CourseTeachers = summarizeRatings(matched)
+
+	// 2. 分页查询，默认按相关度排序，相关度相同时新的在前
+	rank := "bm25(course_recommendations_fts, " + searchColumnWeights + ")"
+	orderSQL := rank + ", r.recommendation_time DESC"
+	switch req.Sort {
+	case "helpful":
+		orderSQL = "r.helpful_count DESC, " + rank + ", r.recommendation_time DESC"
+	case "latest":
+		orderSQL = "r.recommendation_time DESC"
+	}
+	offset := (page - 1) * pageSize
+	rows, err = db.Query(`
+		SELECT r.id, r.course_name, r.teacher_name, r.recommendation_reason, r.recommender_nickname, r.recommendation_time, r.campus, r.recommendation_year,
+			r.rating_difficulty, r.rating_workload, r.rating_grading, r.rating_attendance, r.rating_overall, r.helpful_count
+		`+fromSQL+`
+		ORDER BY `+orderSQL+`
+		LIMIT ? OFFSET ?
+	`, append(args, pageSize, offset)...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var list []model.CourseRecommendationPublic
 	for rows.Next() {
 		var r model.CourseRecommendationPublic
-		if err := rows.Scan(&r.CourseName, &r.TeacherName, &r.RecommendationReason, &r.RecommenderNickname, &r.RecommendationTime, &r.Campus, &r.RecommendationYear); err != nil {
+		if err := rows.Scan(&r.ID, &r.CourseName, &r.TeacherName, &r.RecommendationReason, &r.RecommenderNickname, &r.RecommendationTime, &r.Campus, &r.RecommendationYear,
+			&r.Ratings.Difficulty, &r.Ratings.Workload, &r.Ratings.GradingLeniency, &r.Ratings.AttendanceStrictness, &r.Ratings.Overall, &r.HelpfulCount); err != nil {
 			continue
 		}
-		list = append(list, r)
+		resp.List = append(resp.List, r)
 	}
 
-	if list == nil {
-		return []model.CourseRecommendationPublic{}, nil
-	}
-	return list, nil
+	return resp, nil
 }
 
-// Recommend 提交课程推荐
-func Recommend(req model.CourseRecommendationRecommendRequest) (int64, error) {
+// FindByCourseNames 按课程名称批量查询可见的课程推荐，返回以传入的课程名称为键的结果
+// 名称通过别名匹配规范课程，所以 "高数A" 也能查到 "高等数学A" 的推荐
+func FindByCourseNames(names []string) (map[string][]model.CourseRecommendationPublic, error) {
+	result := make(map[string][]model.CourseRecommendationPublic)
+	if len(names) == 0 {
+		return result, nil
+	}
+
 	db := database.GetCourseRecDB()
 	if db == nil {
-		return 0, errors.New("数据库连接失败")
+		return nil, errors.New("数据库连接失败")
+	}
+
+	namesByKey := make(map[string][]string)
+	for _, name := range names {
+		key := aliasKeyOf(name)
+		namesByKey[key] = append(namesByKey[key], name)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(namesByKey)), ",")
+	args := make([]any, 0, len(namesByKey))
+	for key := range namesByKey {
+		args = append(args, key)
+	}
+
+	rows, err := db.Query(`
+		SELECT a.alias_key, r.id, r.course_name, r.teacher_name, r.recommendation_reason, r.recommender_nickname, r.recommendation_time, r.campus, r.recommendation_year,
+			r.rating_difficulty, r.rating_workload, r.rating_grading, r.rating_attendance, r.rating_overall, r.helpful_count
+		FROM course_recommendations r
+		JOIN course_aliases a ON a.entity_id = r.course_id
+		WHERE r.is_visible = 1 AND a.alias_key IN (`+placeholders+`)
+		ORDER BY r.recommendation_time DESC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var r model.CourseRecommendationPublic
+		if err := rows.Scan(&key, &r.ID, &r.CourseName, &r.TeacherName, &r.RecommendationReason, &r.RecommenderNickname, &r.RecommendationTime, &r.Campus, &r.RecommendationYear,
+			&r.Ratings.Difficulty, &r.Ratings.Workload, &r.Ratings.GradingLeniency, &r.Ratings.AttendanceStrictness, &r.Ratings.Overall, &r.HelpfulCount); err != nil {
+			continue
+		}
+		for _, name := range namesByKey[key] {
+			result[name] = append(result[name], r)
+		}
+	}
+
+	return result, nil
+}
+
+// Recommend 提交课程推荐，返回提交时间和审核状态查询凭证
+// 提交前依次检查提交频率、敏感词和重复内容，命中敏感词时按配置直接拒绝或标记后进入审核队列
+func Recommend(req model.CourseRecommendationRecommendRequest, client model.RecommendationClient) (int64, string, error) {
+	if !allowSubmission(client, time.Now()) {
+		return 0, "", ErrRateLimited
 	}
 
 	nickname := req.RecommenderNickname
@@ -59,19 +189,69 @@ func Recommend(req model.CourseRecommendationRecommendRequest) (int64, error) {
 		nickname = "匿名"
 	}
 
+	flagReason := ""
+	if hits := matchSensitiveWords(config.GetRecommendationSensitiveWords(),
+		req.CourseName, req.TeacherName, req.RecommendationReason, nickname); len(hits) > 0 {
+		if config.GetRecommendationSensitiveAction() == config.SensitiveActionReject {
+			return 0, "", ErrSensitiveContent
+		}
+		flagReason = "命中敏感词: " + strings.Join(hits, ", ")
+	}
+
+	db := database.GetCourseRecDB()
+	if db == nil {
+		return 0, "", errors.New("数据库连接失败")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, "", err
+	}
+	defer tx.Rollback()
+
+	// 关联到规范课程和教师，名称统一使用规范名称
+	linked, err := resolveNames(tx, req.CourseName, req.TeacherName)
+	if err != nil {
+		return 0, "", err
+	}
+	if err := checkDuplicate(tx, linked.courseID, linked.teacherID, req.RecommendationReason); err != nil {
+		return 0, "", err
+	}
+
+	token, tokenHash, err := newStatusToken()
+	if err != nil {
+		return 0, "", err
+	}
+
 	now := time.Now().Unix()
-	_, err := db.Exec(`
-		INSERT INTO course_recommendations (course_name, teacher_name, recommendation_reason, recommender_nickname, recommendation_time, is_visible, campus, recommendation_year)
-		VALUES (?, ?, ?, ?, ?, 0, ?, ?)
-	`, req.CourseName, req.TeacherName, req.RecommendationReason, nickname, now, req.Campus, req.RecommendationYear)
+	result, err := tx.Exec(`
+		INSERT INTO course_recommendations (course_name, teacher_name, course_id, teacher_id, recommendation_reason, recommender_nickname, recommendation_time, is_visible, status, status_token, campus, recommendation_year, flagged, flag_reason, `+ratingColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 0, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, linked.courseName, linked.teacherName, linked.courseID, linked.teacherID, req.RecommendationReason, nickname, now, StatusPending, tokenHash, req.Campus, req.RecommendationYear,
+		flagReason != "", flagReason,
+		req.Ratings.Difficulty, req.Ratings.Workload, req.Ratings.GradingLeniency, req.Ratings.AttendanceStrictness, req.Ratings.Overall)
 	if err != nil {
-		return 0, err
+		return 0, "", err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, "", err
+	}
+	if err := indexRecommendations(tx, "id = ?", id); err != nil {
+		return 0, "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, "", err
 	}
 
 	// 发送飞书通知
-	notify.NotifyNewRecommendation(req.CourseName, req.TeacherName, nickname, req.RecommendationReason)
+	if flagReason != "" {
+		notify.NotifyFlaggedRecommendation(req.CourseName, req.TeacherName, nickname, req.RecommendationReason, flagReason)
+	} else {
+		notify.NotifyNewRecommendation(req.CourseName, req.TeacherName, nickname, req.RecommendationReason)
+	}
 
-	return now, nil
+	return now, token, nil
 }
 
 // Update 更新课程推荐信息（管理员用）
@@ -86,16 +266,35 @@ func Update(req model.CourseRecommendationUpdateRequest) error {
 		nickname = "匿名"
 	}
 
-	visibleInt := 0
-	if req.IsVisible {
-		visibleInt = 1
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current string
+	var oldCourseID, oldTeacherID int64
+	err = tx.QueryRow(`SELECT status, course_id, teacher_id FROM course_recommendations WHERE id = ?`, req.RecommendationID).Scan(&current, &oldCourseID, &oldTeacherID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	linked, err := resolveNames(tx, req.CourseName, req.TeacherName)
+	if err != nil {
+		return err
 	}
 
-	result, err := db.Exec(`
+	result, err := tx.Exec(`
 		UPDATE course_recommendations
-		SET course_name = ?, teacher_name = ?, recommendation_reason = ?, recommender_nickname = ?, is_visible = ?, campus = ?, recommendation_year = ?
+		SET course_name = ?, teacher_name = ?, course_id = ?, teacher_id = ?, recommendation_reason = ?, recommender_nickname = ?, campus = ?, recommendation_year = ?,
+			rating_difficulty = ?, rating_workload = ?, rating_grading = ?, rating_attendance = ?, rating_overall = ?
 		WHERE id = ?
-	`, req.CourseName, req.TeacherName, req.RecommendationReason, nickname, visibleInt, req.Campus, req.RecommendationYear, req.RecommendationID)
+	`, linked.courseName, linked.teacherName, linked.courseID, linked.teacherID, req.RecommendationReason, nickname, req.Campus, req.RecommendationYear,
+		req.Ratings.Difficulty, req.Ratings.Workload, req.Ratings.GradingLeniency, req.Ratings.AttendanceStrictness, req.Ratings.Overall,
+		req.RecommendationID)
 	if err != nil {
 		return err
 	}
@@ -105,34 +304,43 @@ func Update(req model.CourseRecommendationUpdateRequest) error {
 		return ErrNotFound
 	}
 
-	return nil
-}
-
-// Review 审核课程推荐（设置是否可见）
-func Review(recommendationID int64, isVisible bool) error {
-	db := database.GetCourseRecDB()
-	if db == nil {
-		return errors.New("数据库连接失败")
+	// 审核状态有变化时才更新审核记录，只编辑内容不会覆盖原审核人和拒绝原因
+	status := updatedStatus(req, current)
+	if status != current {
+		if err := setStatus(tx, req.RecommendationID, status, "", ""); err != nil {
+			return err
+		}
+	} else if status == StatusRejected {
+		// 被拒绝的推荐不关联实体，上面按新名称建立的关联需要解除
+		if err := unlinkRecommendation(tx, req.RecommendationID); err != nil {
+			return err
+		}
 	}
 
-	visibleInt := 0
-	if isVisible {
-		visibleInt = 1
+	// 修改名称后原来关联的实体可能不再被引用
+	if err := pruneEntities(tx, oldCourseID, oldTeacherID); err != nil {
+		return err
 	}
 
-	result, err := db.Exec(`
-		UPDATE course_recommendations SET is_visible = ? WHERE id = ?
-	`, visibleInt, recommendationID)
-	if err != nil {
+	if err := indexRecommendations(tx, "id = ?", req.RecommendationID); err != nil {
 		return err
 	}
+	return tx.Commit()
+}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return ErrNotFound
+// updatedStatus 计算编辑后的审核状态
+// 未指定 status 时兼容旧版的 is_visible：可见即通过，已通过的改为不可见则隐藏，其余状态保持不变
+func updatedStatus(req model.CourseRecommendationUpdateRequest, current string) string {
+	switch {
+	case req.Status != "":
+		return req.Status
+	case req.IsVisible:
+		return StatusApproved
+	case current == StatusApproved:
+		return StatusHidden
+	default:
+		return current
 	}
-
-	return nil
 }
 
 // GetAll 获取所有课程推荐（管理员用，包含不可见的）
@@ -142,18 +350,22 @@ func GetAll(page, pageSize int, status string) ([]model.CourseRecommendation, in
 		return nil, 0, errors.New("数据库连接失败")
 	}
 
-	// 构建查询条件
+	// 构建查询条件，flagged 为命中敏感词、尚待审核的推荐，all 或未知状态返回全部
 	whereSQL := ""
-	if status == "pending" {
-		whereSQL = "WHERE is_visible = 0"
-	} else if status == "approved" {
-		whereSQL = "WHERE is_visible = 1"
+	var args []any
+	switch status {
+	case StatusPending, StatusApproved, StatusRejected, StatusHidden:
+		whereSQL = "WHERE status = ?"
+		args = append(args, status)
+	case "flagged":
+		whereSQL = "WHERE status = ? AND flagged = 1"
+		args = append(args, StatusPending)
 	}
 
 	// 1. 获取总数
 	var total int64
 	countQuery := "SELECT COUNT(*) FROM course_recommendations " + whereSQL
-	err := db.QueryRow(countQuery).Scan(&total)
+	err := db.QueryRow(countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -161,14 +373,17 @@ func GetAll(page, pageSize int, status string) ([]model.CourseRecommendation, in
 	// 2. 分页查询
 	offset := (page - 1) * pageSize
 	query := `
-		SELECT id, course_name, teacher_name, recommendation_reason, recommender_nickname, recommendation_time, is_visible, campus, recommendation_year
+		SELECT id, course_name, teacher_name, recommendation_reason, recommender_nickname, recommendation_time, is_visible, campus, recommendation_year, flagged, flag_reason, helpful_count,
+			status, reviewer, reviewed_at, reject_reason,
+			(SELECT COUNT(*) FROM recommendation_reports p WHERE p.recommendation_id = course_recommendations.id AND p.status = 'open'),
+			` + ratingColumns + `
 		FROM course_recommendations
 		` + whereSQL + `
 		ORDER BY recommendation_time DESC
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := db.Query(query, pageSize, offset)
+	rows, err := db.Query(query, append(args, pageSize, offset)...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -177,7 +392,9 @@ func GetAll(page, pageSize int, status string) ([]model.CourseRecommendation, in
 	var list []model.CourseRecommendation
 	for rows.Next() {
 		var r model.CourseRecommendation
-		if err := rows.Scan(&r.ID, &r.CourseName, &r.TeacherName, &r.RecommendationReason, &r.RecommenderNickname, &r.RecommendationTime, &r.IsVisible, &r.Campus, &r.RecommendationYear); err != nil {
+		if err := rows.Scan(&r.ID, &r.CourseName, &r.TeacherName, &r.RecommendationReason, &r.RecommenderNickname, &r.RecommendationTime, &r.IsVisible, &r.Campus, &r.RecommendationYear, &r.Flagged, &r.FlagReason, &r.HelpfulCount,
+			&r.Status, &r.Reviewer, &r.ReviewedAt, &r.RejectReason, &r.OpenReports,
+			&r.Ratings.Difficulty, &r.Ratings.Workload, &r.Ratings.GradingLeniency, &r.Ratings.AttendanceStrictness, &r.Ratings.Overall); err != nil {
 			continue
 		}
 		list = append(list, r)
@@ -189,22 +406,44 @@ func GetAll(page, pageSize int, status string) ([]model.CourseRecommendation, in
 	return list, total, nil
 }
 
-// Delete 删除课程推荐（管理员用）
+// Delete 删除课程推荐（管理员用），同时清理不再被引用的课程和教师实体
 func Delete(recommendationID int64) error {
 	db := database.GetCourseRecDB()
 	if db == nil {
 		return errors.New("数据库连接失败")
 	}
 
-	result, err := db.Exec(`DELETE FROM course_recommendations WHERE id = ?`, recommendationID)
+	tx, err := db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
+	if err := deleteRecommendation(tx, recommendationID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// deleteRecommendation 删除推荐及其投票和全文索引，并清理不再被引用的课程和教师
+func deleteRecommendation(q sqlExecutor, recommendationID int64) error {
+	var courseID, teacherID int64
+	err := q.QueryRow(`SELECT course_id, teacher_id FROM course_recommendations WHERE id = ?`, recommendationID).Scan(&courseID, &teacherID)
+	if errors.Is(err, sql.ErrNoRows) {
 		return ErrNotFound
 	}
+	if err != nil {
+		return err
+	}
 
-	return nil
+	if _, err := q.Exec(`DELETE FROM course_recommendations WHERE id = ?`, recommendationID); err != nil {
+		return err
+	}
+	if _, err := q.Exec(`DELETE FROM recommendation_votes WHERE recommendation_id = ?`, recommendationID); err != nil {
+		return err
+	}
+	if _, err := q.Exec(`DELETE FROM course_recommendations_fts WHERE rowid = ?`, recommendationID); err != nil {
+		return err
+	}
+	return pruneEntities(q, courseID, teacherID)
 }
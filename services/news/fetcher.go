@@ -0,0 +1,240 @@
+package news
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+	"github.com/go-resty/resty/v2"
+)
+
+// 默认抓取教务处官网的通知公告栏目
+// 通过环境变量 NEWS_BASE_URL / NEWS_LIST_PATH 可以指向本地的替身站点，方便调试
+const (
+	defaultBaseURL  = "https://jwc.qfnu.edu.cn"
+	defaultListPath = "/tzgg.htm"
+)
+
+var (
+	reArticleLink = regexp.MustCompile(`info/(\d+)/(\d+)\.htm`)
+	reDate        = regexp.MustCompile(`(\d{4})[-/年.](\d{1,2})[-/月.](\d{1,2})`)
+	reAttachment  = regexp.MustCompile(`(?i)download\.jsp|\.(pdf|docx?|xlsx?|pptx?|zip|rar|7z)$`)
+)
+
+// listURL 返回通知列表页地址
+func listURL() string {
+	base := strings.TrimRight(os.Getenv("NEWS_BASE_URL"), "/")
+	if base == "" {
+		base = defaultBaseURL
+	}
+	path := os.Getenv("NEWS_LIST_PATH")
+	if path == "" {
+		path = defaultListPath
+	}
+	return base + "/" + strings.TrimLeft(path, "/")
+}
+
+func newClient() *resty.Client {
+	return resty.New().
+		SetTimeout(15*time.Second).
+		SetHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+}
+
+// fetchList 抓取通知列表页
+func fetchList() ([]model.NewsItem, error) {
+	target := listURL()
+	resp, err := newClient().R().Get(target)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("list page returned status %d", resp.StatusCode())
+	}
+	return parseListHtml(resp.Body(), target)
+}
+
+// parseListHtml 解析通知列表页
+// 列表项形如 <li><a href="info/1064/12345.htm" title="标题">标题</a><span>2025-01-01</span></li>，
+// 日期可能在链接所在元素的任意位置，这里取所在行的文本做匹配
+func parseListHtml(htmlBody []byte, pageURL string) ([]model.NewsItem, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
+	if err != nil {
+		return nil, err
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]model.NewsItem, 0)
+	seen := make(map[string]bool)
+
+	doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+		href, _ := a.Attr("href")
+		m := reArticleLink.FindStringSubmatch(href)
+		if m == nil {
+			return
+		}
+		id := m[1] + "-" + m[2]
+		if seen[id] {
+			return
+		}
+
+		title := strings.TrimSpace(a.AttrOr("title", ""))
+		if title == "" {
+			title = strings.TrimSpace(a.Text())
+		}
+		if title == "" {
+			return
+		}
+		seen[id] = true
+
+		link, err := base.Parse(href)
+		if err != nil {
+			return
+		}
+
+		items = append(items, model.NewsItem{
+			ID:          id,
+			Title:       title,
+			URL:         link.String(),
+			PublishedAt: findDate(a.Closest("li, tr").Text() + " " + a.Parent().Text()),
+		})
+	})
+
+	return items, nil
+}
+
+// findDate 从文本中提取日期并格式化为 2006-01-02，找不到时返回空字符串
+func findDate(text string) string {
+	m := reDate.FindStringSubmatch(text)
+	if m == nil {
+		return ""
+	}
+	t, err := time.Parse("2006-1-2", m[1]+"-"+strings.TrimLeft(m[2], "0")+"-"+strings.TrimLeft(m[3], "0"))
+	if err != nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// fetchDetail 抓取通知详情页，返回正文纯文本和附件
+func fetchDetail(pageURL string) (string, []model.NewsAttachment, string, error) {
+	resp, err := newClient().R().Get(pageURL)
+	if err != nil {
+		return "", nil, "", err
+	}
+	if resp.StatusCode() != 200 {
+		return "", nil, "", fmt.Errorf("detail page returned status %d", resp.StatusCode())
+	}
+	return parseDetailHtml(resp.Body(), pageURL)
+}
+
+// parseDetailHtml 解析通知详情页
+// 正文在 #vsb_content / .v_news_content 中 (博达站群系统)，找不到时退回到 form[name=_newscontent_fromname]
+// 附件是指向 download.jsp 或常见文档后缀的链接；同时返回文章信息栏中的发布日期
+func parseDetailHtml(htmlBody []byte, pageURL string) (content string, attachments []model.NewsAttachment, publishedAt string, err error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
+	if err != nil {
+		return "", nil, "", err
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	body := doc.Find("#vsb_content, .v_news_content").First()
+	if body.Length() == 0 {
+		body = doc.Find(`form[name="_newscontent_fromname"]`).First()
+	}
+
+	paragraphs := make([]string, 0)
+	body.Find("p, div, li, td").Each(func(_ int, s *goquery.Selection) {
+		// 只取最内层的块，避免嵌套元素重复输出
+		if s.Find("p, div, li, td").Length() > 0 {
+			return
+		}
+		if text := strings.Join(strings.Fields(s.Text()), " "); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	})
+	if len(paragraphs) == 0 {
+		if text := strings.Join(strings.Fields(body.Text()), " "); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	}
+
+	attachments = make([]model.NewsAttachment, 0)
+	seen := make(map[string]bool)
+	doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+		href, _ := a.Attr("href")
+		if !reAttachment.MatchString(href) {
+			return
+		}
+		link, err := base.Parse(href)
+		if err != nil || seen[link.String()] {
+			return
+		}
+		seen[link.String()] = true
+		name := strings.TrimSpace(a.Text())
+		if name == "" {
+			name = link.Path[strings.LastIndex(link.Path, "/")+1:]
+		}
+		attachments = append(attachments, model.NewsAttachment{Name: name, URL: link.String()})
+	})
+
+	return strings.Join(paragraphs, "\n"), attachments, findPublishedDate(doc, body), nil
+}
+
+// findPublishedDate 从文章信息栏 (标题下方的 "发布时间：2025-12-20  来源：教务处") 中提取发布日期
+// 信息栏是正文所在容器中除正文外的部分，优先取带 "发布" / "时间" / "日期" 字样的那一段；
+// 页头、导航和页脚中的日期不参与匹配，只有找不到文章容器时才退回到整页文本
+func findPublishedDate(doc *goquery.Document, body *goquery.Selection) string {
+	meta := articleMeta(doc, body)
+	if meta == nil {
+		return findDate(doc.Find("body").Text())
+	}
+
+	date := ""
+	meta.Find("*").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		// 只看最内层的元素，避免外层容器的文本把标题也算进来
+		if s.Children().Length() > 0 {
+			return true
+		}
+		text := s.Text()
+		if strings.Contains(text, "发布") || strings.Contains(text, "时间") || strings.Contains(text, "日期") {
+			date = findDate(text)
+		}
+		return date == ""
+	})
+	if date == "" {
+		date = findDate(meta.Text())
+	}
+	return date
+}
+
+// articleMeta 返回去掉正文后的文章容器 (标题和信息栏)，找不到时返回 nil
+// 博达站群的文章在 form[name=_newscontent_fromname] 中；其他页面从正文向上找第一个还有其他内容的祖先元素
+func articleMeta(doc *goquery.Document, body *goquery.Selection) *goquery.Selection {
+	strip := func(s *goquery.Selection) *goquery.Selection {
+		clone := s.Clone()
+		clone.Find("#vsb_content, .v_news_content, script, style").Remove()
+		return clone
+	}
+
+	if form := doc.Find(`form[name="_newscontent_fromname"]`).First(); form.Length() > 0 {
+		return strip(form)
+	}
+	for node := body.Parent(); node.Length() > 0 && !node.Is("body, html"); node = node.Parent() {
+		if meta := strip(node); strings.TrimSpace(meta.Text()) != "" {
+			return meta
+		}
+	}
+	return nil
+}
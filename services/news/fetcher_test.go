@@ -0,0 +1,147 @@
+package news
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/W1ndys/easy-qfnu-api-go/internal/database"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// standInSite 本地替身站点，记录详情页被请求的次数
+type standInSite struct {
+	*httptest.Server
+	detailHits atomic.Int32
+}
+
+// newStandInSite 启动一个本地替身站点，通知列表页和详情页使用 testdata 中的样例
+func newStandInSite(t *testing.T) *standInSite {
+	t.Helper()
+	// 有的测试会切换工作目录，这里先取样例文件的绝对路径
+	listPage, _ := filepath.Abs("testdata/tzgg.htm")
+	detailPage, _ := filepath.Abs("testdata/detail.htm")
+
+	site := &standInSite{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tzgg.htm", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, listPage)
+	})
+	mux.HandleFunc("/info/1064/12345.htm", func(w http.ResponseWriter, r *http.Request) {
+		site.detailHits.Add(1)
+		http.ServeFile(w, r, detailPage)
+	})
+	site.Server = httptest.NewServer(mux)
+	t.Cleanup(site.Close)
+	return site
+}
+
+func TestFetchList(t *testing.T) {
+	server := newStandInSite(t)
+	t.Setenv("NEWS_BASE_URL", server.URL)
+	t.Setenv("NEWS_LIST_PATH", "/tzgg.htm")
+
+	items, err := fetchList()
+	if err != nil {
+		t.Fatalf("fetchList() error = %v", err)
+	}
+
+	want := []struct {
+		id, title, url, publishedAt string
+	}{
+		{"1064-12345", "关于2025-2026学年第一学期期末考试安排的通知", server.URL + "/info/1064/12345.htm", "2025-12-20"},
+		{"1064-12344", "关于开展学生评教工作的通知", server.URL + "/info/1064/12344.htm", "2025-12-18"},
+		{"1065-2001", "关于公布转专业名单的公示", server.URL + "/info/1065/2001.htm", "2025-12-01"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("fetchList() returned %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for i, w := range want {
+		got := items[i]
+		if got.ID != w.id || got.Title != w.title || got.URL != w.url || got.PublishedAt != w.publishedAt {
+			t.Errorf("item %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestFetchListBadStatus(t *testing.T) {
+	server := newStandInSite(t)
+	t.Setenv("NEWS_BASE_URL", server.URL)
+	t.Setenv("NEWS_LIST_PATH", "/missing.htm")
+
+	if _, err := fetchList(); err == nil {
+		t.Fatal("fetchList() error = nil, want error for 404 page")
+	}
+}
+
+func TestFetchDetail(t *testing.T) {
+	server := newStandInSite(t)
+
+	content, attachments, publishedAt, err := fetchDetail(server.URL + "/info/1064/12345.htm")
+	if err != nil {
+		t.Fatalf("fetchDetail() error = %v", err)
+	}
+
+	wantContent := "各学院：\n现将本学期期末考试安排通知如下，请各学院做好考务工作。\n考试时间：2026年1月6日起"
+	if content != wantContent {
+		t.Errorf("content = %q, want %q", content, wantContent)
+	}
+
+	// 页头的 "今天是 2026-01-05" 和页脚的 "更新于 2025/12/31" 不能当作发布日期
+	if publishedAt != "2025-12-20" {
+		t.Errorf("publishedAt = %q, want %q", publishedAt, "2025-12-20")
+	}
+
+	wantAttachments := []model.NewsAttachment{
+		{Name: "期末考试安排表.xlsx", URL: server.URL + "/system/_content/download.jsp?urltype=news.DownloadAttachUrl&wbfileid=100"},
+		{Name: "rules.pdf", URL: server.URL + "/docs/rules.pdf"},
+	}
+	if len(attachments) != len(wantAttachments) {
+		t.Fatalf("attachments = %+v, want %+v", attachments, wantAttachments)
+	}
+	for i, w := range wantAttachments {
+		if attachments[i] != w {
+			t.Errorf("attachment %d = %+v, want %+v", i, attachments[i], w)
+		}
+	}
+}
+
+func TestDetailCachesFetchedPage(t *testing.T) {
+	server := newStandInSite(t)
+	// 数据库文件建在临时目录中
+	wd, _ := os.Getwd()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		database.Close()
+		os.Chdir(wd)
+	})
+
+	db := database.GetAppDB()
+	if _, err := db.Exec(`INSERT INTO news (id, title, url, fetched_at) VALUES (?, ?, ?, ?)`,
+		"1064-12345", "关于2025-2026学年第一学期期末考试安排的通知", server.URL+"/info/1064/12345.htm", time.Now().Unix()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		detail, err := Detail("1064-12345")
+		if err != nil {
+			t.Fatalf("Detail() call %d error = %v", i+1, err)
+		}
+		if detail.PublishedAt != "2025-12-20" || len(detail.Attachments) != 2 || detail.Content == "" {
+			t.Errorf("Detail() call %d = %+v", i+1, detail)
+		}
+	}
+	if hits := server.detailHits.Load(); hits != 1 {
+		t.Errorf("detail page fetched %d times, want 1", hits)
+	}
+
+	if _, err := Detail("1064-0"); err != ErrNotFound {
+		t.Errorf("Detail() unknown id error = %v, want ErrNotFound", err)
+	}
+}
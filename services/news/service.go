@@ -0,0 +1,268 @@
+package news
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/W1ndys/easy-qfnu-api-go/common/notify"
+	"github.com/W1ndys/easy-qfnu-api-go/internal/database"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+var (
+	ErrNotFound = errors.New("通知不存在")
+)
+
+// 列表缓存超过该时长时，查询列表会在后台刷新一次
+const listTTL = 30 * time.Minute
+
+// 单次刷新最多推送的新通知数量，避免首次部署或长时间停机后刷屏
+const maxNotifyPerRefresh = 5
+
+// 刷新失败后的重试间隔，避免上游不可用时每个请求都去等待超时
+const retryInterval = time.Minute
+
+// refreshMu 保证同一时间只有一次刷新在抓取上游
+var refreshMu sync.Mutex
+
+// refreshState 记录刷新时间，只在读写时短暂加锁，不会被正在进行的抓取阻塞
+var refreshState = struct {
+	sync.Mutex
+	lastRefreshed int64
+	lastAttempt   int64
+}{}
+
+// List 分页查询缓存的通知列表，缓存过期时在后台刷新，本次请求直接返回已有缓存
+// 不在请求中同步刷新，避免上游缓慢时用户请求一直等到超时
+func List(req model.NewsListRequest) (*model.NewsListResponse, error) {
+	db := database.GetAppDB()
+	if db == nil {
+		return nil, errors.New("数据库连接失败")
+	}
+
+	if claimRefresh(time.Now()) {
+		go func() {
+			if _, err := Refresh(); err != nil {
+				slog.Warn("后台刷新通知公告失败", "error", err)
+			}
+		}()
+	}
+
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	whereSQL := ""
+	args := []any{}
+	if keyword := strings.TrimSpace(req.Keyword); keyword != "" {
+		whereSQL = "WHERE title LIKE ?"
+		args = append(args, "%"+keyword+"%")
+	}
+
+	var total int64
+	if err := db.QueryRow("SELECT COUNT(*) FROM news "+whereSQL, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`
+		SELECT id, title, url, published_at, fetched_at
+		FROM news
+		`+whereSQL+`
+		ORDER BY published_at DESC, fetched_at DESC
+		LIMIT ? OFFSET ?
+	`, append(args, pageSize, (page-1)*pageSize)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.NewsItem{}
+	for rows.Next() {
+		var item model.NewsItem
+		if err := rows.Scan(&item.ID, &item.Title, &item.URL, &item.PublishedAt, &item.FetchedAt); err != nil {
+			continue
+		}
+		list = append(list, item)
+	}
+
+	return &model.NewsListResponse{
+		List:          list,
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+		LastRefreshed: lastRefreshed(),
+	}, nil
+}
+
+// Detail 获取通知详情，正文未缓存时抓取详情页并写入缓存
+func Detail(id string) (*model.NewsDetail, error) {
+	db := database.GetAppDB()
+	if db == nil {
+		return nil, errors.New("数据库连接失败")
+	}
+
+	var detail model.NewsDetail
+	var attachments string
+	var detailFetchedAt int64
+	err := db.QueryRow(`
+		SELECT id, title, url, published_at, fetched_at, content, attachments, detail_fetched_at
+		FROM news WHERE id = ?
+	`, strings.TrimSpace(id)).Scan(&detail.ID, &detail.Title, &detail.URL, &detail.PublishedAt, &detail.FetchedAt,
+		&detail.Content, &attachments, &detailFetchedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if detailFetchedAt == 0 {
+		content, files, publishedAt, err := fetchDetail(detail.URL)
+		if err != nil {
+			return nil, err
+		}
+		if detail.PublishedAt == "" {
+			detail.PublishedAt = publishedAt
+		}
+		detail.Content = content
+		detail.Attachments = files
+
+		encoded, _ := json.Marshal(files)
+		db.Exec(`
+			UPDATE news SET content = ?, attachments = ?, published_at = ?, detail_fetched_at = ?
+			WHERE id = ?
+		`, content, string(encoded), detail.PublishedAt, time.Now().Unix(), detail.ID)
+		return &detail, nil
+	}
+
+	if err := json.Unmarshal([]byte(attachments), &detail.Attachments); err != nil || detail.Attachments == nil {
+		detail.Attachments = []model.NewsAttachment{}
+	}
+	return &detail, nil
+}
+
+// Refresh 抓取通知列表并写入缓存，返回新出现的通知
+// 缓存为空时 (首次抓取) 不推送通知，避免把历史通知全部推送一遍
+func Refresh() ([]model.NewsItem, error) {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+
+	db := database.GetAppDB()
+	if db == nil {
+		return nil, errors.New("数据库连接失败")
+	}
+
+	refreshState.Lock()
+	refreshState.lastAttempt = time.Now().Unix()
+	refreshState.Unlock()
+	items, err := fetchList()
+	if err != nil {
+		return nil, err
+	}
+
+	var existing int64
+	db.QueryRow("SELECT COUNT(*) FROM news").Scan(&existing)
+
+	now := time.Now().Unix()
+	fresh := make([]model.NewsItem, 0)
+	for _, item := range items {
+		item.FetchedAt = now
+		result, err := db.Exec(`
+			INSERT INTO news (id, title, url, published_at, fetched_at)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(id) DO NOTHING
+		`, item.ID, item.Title, item.URL, item.PublishedAt, item.FetchedAt)
+		if err != nil {
+			return nil, err
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			fresh = append(fresh, item)
+		} else {
+			// 标题可能被修改过，以最新的为准
+			db.Exec(`UPDATE news SET title = ? WHERE id = ?`, item.Title, item.ID)
+		}
+	}
+	refreshState.Lock()
+	refreshState.lastRefreshed = now
+	refreshState.Unlock()
+
+	if existing > 0 && len(fresh) > 0 && notifyEnabled() {
+		notifyNews(fresh)
+	}
+	return fresh, nil
+}
+
+// notifyNews 推送新通知
+func notifyNews(items []model.NewsItem) {
+	if len(items) > maxNotifyPerRefresh {
+		items = items[:maxNotifyPerRefresh]
+	}
+	var b strings.Builder
+	b.WriteString("**📢 教务处发布了新通知**\n")
+	for _, item := range items {
+		fmt.Fprintf(&b, "\n- [%s](%s) %s", item.Title, item.URL, item.PublishedAt)
+	}
+	notify.NotifyCustom("教务处新通知", b.String(), "blue")
+}
+
+// notifyEnabled 是否推送新通知，通过环境变量 NEWS_NOTIFY=true 开启
+func notifyEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("NEWS_NOTIFY"))
+	return enabled
+}
+
+// claimRefresh 列表缓存过期且距离上次尝试已超过重试间隔时返回 true，并记下本次尝试
+// 同时到达的请求只有一个会触发后台刷新
+func claimRefresh(now time.Time) bool {
+	refreshState.Lock()
+	defer refreshState.Unlock()
+	if now.Unix()-refreshState.lastRefreshed <= int64(listTTL.Seconds()) ||
+		now.Unix()-refreshState.lastAttempt <= int64(retryInterval.Seconds()) {
+		return false
+	}
+	refreshState.lastAttempt = now.Unix()
+	return true
+}
+
+func lastRefreshed() int64 {
+	refreshState.Lock()
+	defer refreshState.Unlock()
+	return refreshState.lastRefreshed
+}
+
+// StartRefresher 启动后台定时刷新，间隔由环境变量 NEWS_REFRESH_MINUTES 指定，默认 30 分钟，0 表示关闭
+func StartRefresher() {
+	interval := 30
+	if raw := os.Getenv("NEWS_REFRESH_MINUTES"); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil {
+			interval = v
+		}
+	}
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+		defer ticker.Stop()
+		for {
+			if fresh, err := Refresh(); err != nil {
+				slog.Warn("定时刷新通知公告失败", "error", err)
+			} else if len(fresh) > 0 {
+				slog.Info("抓取到新的通知公告", "count", len(fresh))
+			}
+			<-ticker.C
+		}
+	}()
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/W1ndys/easy-qfnu-api-go/model"
+	calendarService "github.com/W1ndys/easy-qfnu-api-go/services/calendar"
 )
 
 // FetchClassSchedules 抓取并解析课程表
@@ -40,7 +41,46 @@ func FetchClassSchedules(cookie string, date string) (*model.ClassScheduleRespon
 	}
 
 	// 解析 HTML
-	return parseClassSchedulesHtml(resp.Body())
+	response, err := parseClassSchedulesHtml(resp.Body())
+	if err != nil {
+		return nil, err
+	}
+
+	// 根据当前周次推算学期开始日期，并写入缓存
+	fillSemesterStart(response, date)
+	fillCalendarDay(response, date)
+	return response, nil
+}
+
+// fillCalendarDay 附加管理员维护的校历信息 (放假、调休补课)，校历中没有该日期时保持为空
+func fillCalendarDay(response *model.ClassScheduleResponse, date string) {
+	queryDate, err := parseScheduleDate(date)
+	if err != nil {
+		return
+	}
+
+	day, err := calendarService.Resolve(queryDate)
+	if err != nil || (!day.InTerm && day.DayType == "") {
+		return
+	}
+	response.Calendar = day
+}
+
+// fillSemesterStart 根据查询日期和当前周次推算学期开始日期，并更新缓存
+// 查询日期不在教学周历内时无法推算，保持为空
+func fillSemesterStart(response *model.ClassScheduleResponse, date string) {
+	if !response.InTerm {
+		return
+	}
+
+	queryDate, err := parseScheduleDate(date)
+	if err != nil {
+		return
+	}
+
+	start := inferSemesterStart(queryDate, response.CurrentWeek)
+	rememberSemesterStart(start, response.TotalWeeks)
+	response.SemesterStart = start.Format("2006-01-02")
 }
 
 // parseClassSchedulesHtml 解析课程表 HTML
@@ -68,6 +108,7 @@ func parseClassSchedulesHtml(htmlBody []byte) (*model.ClassScheduleResponse, err
 		// 处理转义字符
 		cleanWeek = strings.ReplaceAll(cleanWeek, `\"`, `"`)
 		response.CurrentWeekRaw = cleanWeek
+		response.CurrentWeek, response.TotalWeeks, response.InTerm = parseCurrentWeek(cleanWeek)
 	}
 
 	var courses []model.ClassSchedules
@@ -14,6 +14,21 @@ var ErrCookieExpired = errors.New("cookie_expired_or_invalid")
 // 未查询到数据 类型错误
 var ErrResourceNotFound = errors.New("resource_not_found")
 
+// 查询日期不在教学周历内
+var ErrDateNotInTerm = errors.New("date_not_in_term")
+
+// 无法推断当前是第几学期
+var ErrSemesterUnknown = errors.New("semester_unknown")
+
+// 当前不在选课时间内，选课课程列表不可用
+var ErrSelectionNotOpen = errors.New("selection_not_open")
+
+// 有未完成的评教，教务系统暂不允许查询成绩
+var ErrEvaluationPending = errors.New("evaluation_pending")
+
+// 排课规划的候选课程或教学班数量超过上限
+var ErrPlannerTooLarge = errors.New("planner_input_too_large")
+
 // NewJwcClient 创建一个配置好“自动检查机制”的 Resty 客户端
 func NewClient(Authorization string) *resty.Client {
 	client := resty.New()
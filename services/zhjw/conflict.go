@@ -0,0 +1,217 @@
+package zhjw
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// examSlot 解析出时间的考试
+type examSlot struct {
+	exam  model.ExamSchedule
+	start time.Time
+	end   time.Time
+}
+
+// DetectConflicts 合并学期课表、考试安排和选课结果，检测时间冲突
+func DetectConflicts(cookie string, term string) (*model.ConflictCheckResponse, error) {
+	term = strings.TrimSpace(term)
+	response := &model.ConflictCheckResponse{
+		Term:      term,
+		Conflicts: []model.ScheduleConflict{},
+		Warnings:  []string{},
+	}
+
+	// 1. 抓取三份数据，"未查询到数据" 视为空列表
+	classes, err := FetchTermClassSchedules(cookie, term)
+	if err != nil && !errors.Is(err, ErrResourceNotFound) {
+		return nil, err
+	}
+	exams, err := FetchExamSchedules(cookie, term)
+	if err != nil && !errors.Is(err, ErrResourceNotFound) {
+		return nil, err
+	}
+	selections, err := FetchSelectionResults(cookie, term)
+	if err != nil && !errors.Is(err, ErrResourceNotFound) {
+		return nil, err
+	}
+
+	// 选课结果按课程名称索引，用来补充课程编号
+	selectionMap := make(map[string]model.SelectionResult)
+	for _, s := range selections {
+		selectionMap[s.CourseName] = s
+	}
+
+	// 2. 考试已按开始时间排序，时间无法解析的考试不参与检测
+	var slots []examSlot
+	for _, e := range exams {
+		if e.StartTime == 0 {
+			response.Warnings = append(response.Warnings, "无法解析考试时间: "+e.CourseName+" "+e.ExamTime)
+			continue
+		}
+		slots = append(slots, examSlot{exam: e, start: time.Unix(e.StartTime, 0), end: time.Unix(e.EndTime, 0)})
+	}
+
+	// 3. 确定学期开始日期，用于把教学周换算成日期；缓存缺失时用第一场考试的日期推算
+	var probe time.Time
+	if len(slots) > 0 {
+		probe = slots[0].start
+	}
+	semesterStart, hasStart := resolveSemesterStart(cookie, term, probe)
+	if hasStart {
+		response.SemesterStart = semesterStart.Format("2006-01-02")
+	}
+
+	// 4. 课程与课程
+	for i := 0; i < len(classes); i++ {
+		for j := i + 1; j < len(classes); j++ {
+			a, b := classes[i], classes[j]
+			if a.TimeParsed.DayOfWeek != b.TimeParsed.DayOfWeek {
+				continue
+			}
+			periods := intersectInts(a.TimeParsed.PeriodArray, b.TimeParsed.PeriodArray)
+			weeks := intersectInts(a.TimeParsed.Weeks, b.TimeParsed.Weeks)
+			if len(periods) == 0 || len(weeks) == 0 {
+				continue
+			}
+
+			conflict := model.ScheduleConflict{
+				Type:      model.ConflictClassClass,
+				Items:     []model.ConflictItem{classConflictItem(a, selectionMap), classConflictItem(b, selectionMap)},
+				DayOfWeek: a.TimeParsed.DayOfWeek,
+				Periods:   periods,
+				Weeks:     weeks,
+				Dates:     []string{},
+			}
+			if hasStart {
+				for _, w := range weeks {
+					conflict.Dates = append(conflict.Dates, dateOf(semesterStart, w, a.TimeParsed.DayOfWeek).Format("2006-01-02"))
+				}
+			}
+			response.Conflicts = append(response.Conflicts, conflict)
+		}
+	}
+
+	// 5. 考试与课程，需要学期开始日期才能知道考试在第几周
+	if !hasStart && len(slots) > 0 && len(classes) > 0 {
+		response.Warnings = append(response.Warnings, "无法确定学期开始日期，未检查考试与上课的冲突")
+	}
+	if hasStart {
+		for _, slot := range slots {
+			week, dayOfWeek := weekAndDayOf(semesterStart, slot.start)
+			for _, class := range classes {
+				if class.TimeParsed.DayOfWeek != dayOfWeek || !slices.Contains(class.TimeParsed.Weeks, week) {
+					continue
+				}
+				classStart, classEnd, ok := periodSpan(slot.start, class.TimeParsed.PeriodArray)
+				if !ok || !overlaps(slot.start, slot.end, classStart, classEnd) {
+					continue
+				}
+
+				response.Conflicts = append(response.Conflicts, model.ScheduleConflict{
+					Type:      model.ConflictClassExam,
+					Items:     []model.ConflictItem{examConflictItem(slot.exam), classConflictItem(class, selectionMap)},
+					DayOfWeek: dayOfWeek,
+					Periods:   overlappingPeriods(slot.start, slot.end, class.TimeParsed.PeriodArray),
+					Weeks:     []int{week},
+					Dates:     []string{slot.start.Format("2006-01-02")},
+				})
+			}
+		}
+	}
+
+	// 6. 考试与考试
+	for i := 0; i < len(slots); i++ {
+		for j := i + 1; j < len(slots); j++ {
+			a, b := slots[i], slots[j]
+			if !overlaps(a.start, a.end, b.start, b.end) {
+				continue
+			}
+
+			conflict := model.ScheduleConflict{
+				Type:      model.ConflictExamExam,
+				Items:     []model.ConflictItem{examConflictItem(a.exam), examConflictItem(b.exam)},
+				DayOfWeek: (int(a.start.Weekday())+6)%7 + 1,
+				Periods:   []int{},
+				Weeks:     []int{},
+				Dates:     []string{a.start.Format("2006-01-02")},
+			}
+			if hasStart {
+				week, _ := weekAndDayOf(semesterStart, a.start)
+				conflict.Weeks = []int{week}
+			}
+			response.Conflicts = append(response.Conflicts, conflict)
+		}
+	}
+
+	return response, nil
+}
+
+// classConflictItem 把课表中的课程转换为冲突项
+func classConflictItem(class model.ClassSchedules, selectionMap map[string]model.SelectionResult) model.ConflictItem {
+	item := model.ConflictItem{
+		Source:     "class",
+		CourseName: class.Name,
+		Teacher:    class.Teacher,
+		Location:   class.Location,
+		TimeText:   class.RawTimeString,
+	}
+	if s, ok := selectionMap[class.Name]; ok {
+		item.CourseId = s.CourseId
+		item.Selected = true
+		if item.Teacher == "" {
+			item.Teacher = s.Teacher
+		}
+	}
+	return item
+}
+
+// examConflictItem 把考试安排转换为冲突项
+func examConflictItem(exam model.ExamSchedule) model.ConflictItem {
+	return model.ConflictItem{
+		Source:     "exam",
+		CourseName: exam.CourseName,
+		CourseId:   exam.CourseId,
+		Teacher:    exam.Instructor,
+		Location:   exam.ExamRoom,
+		TimeText:   exam.ExamTime,
+	}
+}
+
+// overlappingPeriods 返回与某段时间重叠的节次
+func overlappingPeriods(start, end time.Time, periods []int) []int {
+	result := []int{}
+	for _, p := range periods {
+		pStart, pEnd, ok := periodSpan(start, []int{p})
+		if ok && overlaps(start, end, pStart, pEnd) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// overlaps 判断两个时间段是否重叠 (首尾相接不算重叠)
+func overlaps(aStart, aEnd, bStart, bEnd time.Time) bool {
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
+
+// intersectInts 求两个整数列表的交集，结果升序
+func intersectInts(a, b []int) []int {
+	set := make(map[int]bool, len(a))
+	for _, v := range a {
+		set[v] = true
+	}
+	result := []int{}
+	for _, v := range b {
+		if set[v] {
+			result = append(result, v)
+			delete(set, v)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
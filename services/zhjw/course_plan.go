@@ -28,12 +28,17 @@ func FetchCoursePlan(token string) (*model.CoursePlanResponse, error) {
 		return nil, fmt.Errorf("parse html failed: %w", err)
 	}
 
+	return parseCoursePlanDoc(doc), nil
+}
+
+// parseCoursePlanDoc 解析培养方案页面，个人培养方案和培养方案查询的详情页结构相同
+func parseCoursePlanDoc(doc *goquery.Document) *model.CoursePlanResponse {
 	response := &model.CoursePlanResponse{}
 
-	// 3. 提取培养目标 (第一个 span#pymb)
+	// 1. 提取培养目标 (第一个 span#pymb)
 	response.Objectives = strings.TrimSpace(doc.Find("span#pymb").First().Text())
 
-	// 4. 提取详细说明 (第二个 span#pymb，或者根据上下文查找)
+	// 2. 提取详细说明 (第二个 span#pymb，或者根据上下文查找)
 	// 因为页面上有两个 id="pymb" 的元素，goquery 的 Find("#id") 可能只会返回第一个或所有。
 	// 这里尝试获取所有并取第二个，如果只有一个则为空
 	pymbSelection := doc.Find("span#pymb")
@@ -41,10 +46,10 @@ func FetchCoursePlan(token string) (*model.CoursePlanResponse, error) {
 		response.Description = strings.TrimSpace(pymbSelection.Eq(1).Text())
 	}
 
-	// 5. 解析课程列表
+	// 3. 解析课程列表
 	response.Groups = parseCourseGroups(doc)
 
-	return response, nil
+	return response
 }
 
 func parseCourseGroups(doc *goquery.Document) []model.CourseGroup {
@@ -122,21 +127,16 @@ func parseCourseGroups(doc *goquery.Document) []model.CourseGroup {
 			creditsStr := strings.TrimSpace(cells.Eq(cellOffset + 5).Text())
 			course.Credits, _ = strconv.ParseFloat(creditsStr, 64)
 
-			// 总学时是倒数第二列 (HTML 中是 cells.Length()-2 ? 或者是固定位置?)
-			// HTML 表头有: 讲课, 实践, 讲座, 实验, 设计, 上机, 讨论, 课外, 网络, 总学时(rowspan or separate?)
-			// 总学时在 HTML 中是倒数第2个 td (倒数第1个是开设学期)
-			// 为了稳健，我们取 cells.Last().Prev().Text() ?
-			// 让我们数一下列数：
-			// Base columns after CourseAttr(idx+4): Credits(idx+5), 9 types of hours, Total Hours, Term
-			// So Total Hours is at index + 5 + 9 + 1 = index + 15
-			// Term is at index + 16 (Last)
-
-			// 让我们用相对位置：倒数第一个是学期，倒数第二个是总学时
+			// 列的顺序：学分(idx+5)、9 类学时、总学时、开设学期
+			// 用相对位置：倒数第一个是学期，倒数第二个是总学时，再往前 9 列是各类学时
 			course.Term = strings.TrimSpace(cells.Last().Text())
 
 			// 处理总学时里面可能包含 input 标签的情况
 			hoursText := strings.TrimSpace(cells.Eq(cells.Length() - 2).Text())
 			course.Hours = hoursText
+
+			course.StatusCode, course.StatusGrade = parseCourseStatus(course.Status)
+			course.HourBreakdown = parseCourseHours(cells)
 		}
 
 		if currentGroup != nil && course.CourseName != "" {
@@ -167,3 +167,55 @@ func parseGroupHeader(text string) (name string, required float64, earned float6
 	}
 	return
 }
+
+// parseCourseStatus 解析完成情况，如 "已修(优)" -> (completed, 优)
+func parseCourseStatus(status string) (code string, grade string) {
+	status = strings.TrimSpace(status)
+
+	re := regexp.MustCompile(`^([^\(（]*)[\(（]\s*(.*?)\s*[\)）]`)
+	label := status
+	if matches := re.FindStringSubmatch(status); len(matches) == 3 {
+		label = strings.TrimSpace(matches[1])
+		grade = matches[2]
+	}
+
+	switch {
+	case strings.HasPrefix(label, "免修"):
+		code = model.CourseStatusExempted
+	case strings.HasPrefix(label, "已修"):
+		code = model.CourseStatusCompleted
+	case strings.HasPrefix(label, "在修"), strings.HasPrefix(label, "修读中"):
+		code = model.CourseStatusInProgress
+	case strings.HasPrefix(label, "未修"), label == "":
+		code = model.CourseStatusNotStarted
+	default:
+		code = model.CourseStatusUnknown
+	}
+	return code, grade
+}
+
+// parseCourseHours 解析学时分类，位于总学时之前的 9 列
+func parseCourseHours(cells *goquery.Selection) model.CourseHours {
+	n := cells.Length()
+	num := func(i int) float64 {
+		if i < 0 || i >= n {
+			return 0
+		}
+		v, _ := strconv.ParseFloat(strings.TrimSpace(cells.Eq(i).Text()), 64)
+		return v
+	}
+
+	first := n - 11 // 讲课学时所在列
+	return model.CourseHours{
+		Lecture:         num(first),
+		Practice:        num(first + 1),
+		Seminar:         num(first + 2),
+		Experiment:      num(first + 3),
+		Design:          num(first + 4),
+		Computer:        num(first + 5),
+		Discussion:      num(first + 6),
+		Extracurricular: num(first + 7),
+		Online:          num(first + 8),
+		Total:           num(n - 2),
+	}
+}
@@ -0,0 +1,132 @@
+package zhjw
+
+import (
+	"log/slog"
+	"math"
+	"sort"
+
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+	courseRecService "github.com/W1ndys/easy-qfnu-api-go/services/course_recommendation"
+)
+
+// SuggestNextTermCourses 根据培养方案的开设学期，列出下学期应修读的课程和未修满的选修课组
+func SuggestNextTermCourses(cookie string, term string, semester int) (*model.CourseSuggestionResponse, error) {
+	plan, err := FetchCoursePlan(cookie)
+	if err != nil {
+		return nil, err
+	}
+	grades, err := FetchGrades(cookie, "", "", "", "all")
+	if err != nil {
+		return nil, err
+	}
+
+	if semester <= 0 {
+		semester = semesterIndexOf(entryYearOf(grades.Grades), currentTermOr(term))
+	}
+	if semester <= 0 {
+		return nil, ErrSemesterUnknown
+	}
+
+	response := suggestNextTermCourses(plan, grades.Grades, semester)
+	annotateSuggestions(response)
+	return response, nil
+}
+
+// suggestNextTermCourses 选课建议的核心计算逻辑
+func suggestNextTermCourses(plan *model.CoursePlanResponse, grades []model.Grade, semester int) *model.CourseSuggestionResponse {
+	gradeIdx := newGradeIndex(grades)
+	next := semester + 1
+
+	response := &model.CourseSuggestionResponse{
+		CurrentSemester: semester,
+		NextSemester:    next,
+		Courses:         []model.CourseSuggestion{},
+		ElectiveGroups:  []model.ElectiveGroupSuggestion{},
+	}
+
+	for _, group := range plan.Groups {
+		remaining := round2(math.Max(0, group.RequiredCredits-group.EarnedCredits))
+		if remaining == 0 {
+			continue
+		}
+
+		var candidates []model.CourseSuggestion
+		hasElective := false
+		for _, course := range group.Courses {
+			if gradeIdx.coursePassed(course) {
+				continue
+			}
+			suggestion := model.CourseSuggestion{
+				CourseCode:      course.CourseCode,
+				CourseName:      course.CourseName,
+				GroupName:       group.GroupName,
+				CourseProp:      course.CourseProp,
+				CourseAttr:      course.CourseAttr,
+				Credits:         course.Credits,
+				Term:            course.Term,
+				Recommendations: []model.CourseRecommendationPublic{},
+			}
+
+			if course.CourseAttr != "必修" {
+				hasElective = true
+				candidates = append(candidates, suggestion)
+				continue
+			}
+			if planTermIndex(course.Term) == next {
+				response.Courses = append(response.Courses, suggestion)
+			}
+		}
+
+		if !hasElective {
+			continue
+		}
+
+		// 下学期开设的课程排在前面
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return planTermIndex(candidates[i].Term) == next && planTermIndex(candidates[j].Term) != next
+		})
+		response.ElectiveGroups = append(response.ElectiveGroups, model.ElectiveGroupSuggestion{
+			GroupName:        group.GroupName,
+			RequiredCredits:  group.RequiredCredits,
+			EarnedCredits:    group.EarnedCredits,
+			RemainingCredits: remaining,
+			Candidates:       candidates,
+		})
+	}
+
+	return response
+}
+
+// annotateSuggestions 为每门建议课程附上选课推荐模块中已审核通过的推荐
+// 推荐数据只是补充信息，查询失败时记录日志后直接返回
+func annotateSuggestions(response *model.CourseSuggestionResponse) {
+	var names []string
+	for _, c := range response.Courses {
+		names = append(names, c.CourseName)
+	}
+	for _, g := range response.ElectiveGroups {
+		for _, c := range g.Candidates {
+			names = append(names, c.CourseName)
+		}
+	}
+
+	recs, err := courseRecService.FindByCourseNames(names)
+	if err != nil {
+		slog.Warn("查询选课推荐失败", "error", err)
+		return
+	}
+
+	for i := range response.Courses {
+		if list, ok := recs[response.Courses[i].CourseName]; ok {
+			response.Courses[i].Recommendations = list
+		}
+	}
+	for i := range response.ElectiveGroups {
+		candidates := response.ElectiveGroups[i].Candidates
+		for j := range candidates {
+			if list, ok := recs[candidates[j].CourseName]; ok {
+				candidates[j].Recommendations = list
+			}
+		}
+	}
+}
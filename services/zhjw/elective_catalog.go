@@ -0,0 +1,301 @@
+package zhjw
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+	courseRecService "github.com/W1ndys/easy-qfnu-api-go/services/course_recommendation"
+	"github.com/go-resty/resty/v2"
+)
+
+// electiveCatalogPaths 各类选课课程列表对应的上游接口
+// 这些接口只在选课轮次开放期间可用，且需要先进入过选课页面 (xsxk_index)
+var electiveCatalogPaths = map[string]string{
+	"public":      "xsxkGgxxkxk", // 公选课
+	"elective":    "xsxkXxxk",    // 选修课
+	"required":    "xsxkBxxk",    // 必修课
+	"plan":        "xsxkBxqjhxk", // 本学期计划
+	"cross_grade": "xsxkKnjxk",   // 专业内跨年级
+	"cross_major": "xsxkFawxk",   // 跨专业
+}
+
+const (
+	// electiveCatalogBatchSize 需要本地筛选时每次向上游请求的课程数
+	electiveCatalogBatchSize = 200
+	// electiveCatalogMaxBatches 需要本地筛选时最多请求的次数，防止课程目录异常庞大时长时间占用上游
+	electiveCatalogMaxBatches = 20
+)
+
+// FetchElectiveCatalog 查询当前选课轮次中可选的课程 (只读，不会进行选课操作)
+func FetchElectiveCatalog(cookie string, req model.ElectiveCatalogRequest) (*model.ElectiveCatalogResponse, error) {
+	kind := strings.TrimSpace(req.Kind)
+	if kind == "" {
+		kind = "public"
+	}
+	path, ok := electiveCatalogPaths[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown course kind: %s", kind)
+	}
+
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 200 {
+		pageSize = 50
+	}
+
+	client := NewClient(cookie)
+
+	queryParams := map[string]string{
+		"kcxx": strings.TrimSpace(req.Keyword), // 课程信息
+		"skls": strings.TrimSpace(req.Teacher), // 上课老师
+		"sfym": "false",                        // 是否过滤已满
+		"sfct": "false",                        // 是否过滤冲突
+	}
+	if req.Day > 0 {
+		queryParams["skxq"] = strconv.Itoa(req.Day) // 上课星期
+	}
+	if req.Period > 0 {
+		queryParams["skjc"] = strconv.Itoa(req.Period) // 上课节次
+	}
+
+	slog.Info("开始查询选课课程目录",
+		"kind", kind,
+		"keyword", req.Keyword,
+		"cookie_len", len(cookie), // 不要记录完整 cookie，记录长度即可，保护隐私
+	)
+
+	// campus=auto 时按学籍信息中的校区筛选
+	if strings.TrimSpace(req.Campus) == "auto" {
+		req.Campus = profileCampus(cookie)
+	}
+
+	// 没有本地筛选条件时直接使用上游分页
+	if !hasLocalElectiveFilter(req) {
+		total, courses, err := fetchElectiveCatalogPage(client, path, queryParams, (page-1)*pageSize, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		annotateElectiveCourses(courses)
+		return &model.ElectiveCatalogResponse{Kind: kind, Total: total, Courses: courses}, nil
+	}
+
+	// 校区和时间段需要在本地筛选，先取回全部课程再分页，否则每页条数和总数都不准确
+	var all []model.ElectiveCourse
+	for batch := 0; batch < electiveCatalogMaxBatches; batch++ {
+		total, courses, err := fetchElectiveCatalogPage(client, path, queryParams, batch*electiveCatalogBatchSize, electiveCatalogBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, courses...)
+		if len(courses) < electiveCatalogBatchSize || len(all) >= total {
+			break
+		}
+		if batch == electiveCatalogMaxBatches-1 {
+			slog.Warn("选课课程目录过大，只筛选了前一部分", "kind", kind, "total", total, "fetched", len(all))
+		}
+	}
+
+	filtered := filterElectiveCourses(all, req)
+	from := min((page-1)*pageSize, len(filtered))
+	to := min(from+pageSize, len(filtered))
+	courses := filtered[from:to]
+	annotateElectiveCourses(courses)
+
+	return &model.ElectiveCatalogResponse{
+		Kind:    kind,
+		Total:   len(filtered),
+		Courses: courses,
+	}, nil
+}
+
+// hasLocalElectiveFilter 判断是否有需要在本地筛选的条件
+func hasLocalElectiveFilter(req model.ElectiveCatalogRequest) bool {
+	return strings.TrimSpace(req.Campus) != "" || req.Day > 0 || req.Period > 0
+}
+
+// fetchElectiveCatalogPage 从上游取回一页选课课程，start 为起始记录下标
+func fetchElectiveCatalogPage(client *resty.Client, path string, queryParams map[string]string, start, length int) (int, []model.ElectiveCourse, error) {
+	resp, err := client.R().
+		SetQueryParams(queryParams).
+		SetFormData(map[string]string{
+			"sEcho":          "1",
+			"iColumns":       "13",
+			"iDisplayStart":  strconv.Itoa(start),
+			"iDisplayLength": strconv.Itoa(length),
+		}).
+		Post("http://zhjw.qfnu.edu.cn/jsxsd/xsxkkc/" + path)
+	if err != nil {
+		return 0, nil, err
+	}
+	return parseElectiveCatalogJson(resp.Body())
+}
+
+// parseElectiveCatalogJson 解析选课课程列表 (DataTables 格式的 JSON)
+// 不在选课时间内时上游返回的是提示页面而不是 JSON
+func parseElectiveCatalogJson(body []byte) (int, []model.ElectiveCourse, error) {
+	var payload struct {
+		ITotalRecords json.Number      `json:"iTotalRecords"`
+		AaData        []map[string]any `json:"aaData"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return 0, nil, ErrSelectionNotOpen
+	}
+
+	courses := make([]model.ElectiveCourse, 0, len(payload.AaData))
+	for _, row := range payload.AaData {
+		get := func(key string) string {
+			v, ok := row[key]
+			if !ok || v == nil {
+				return ""
+			}
+			return strings.TrimSpace(fmt.Sprint(v))
+		}
+		getInt := func(key string) int {
+			n, _ := strconv.ParseFloat(get(key), 64)
+			return int(n)
+		}
+
+		course := model.ElectiveCourse{
+			ClassID:         get("jx0404id"),
+			CourseCode:      get("kch"),
+			CourseName:      get("kcmc"),
+			Credit:          get("xf"),
+			Teacher:         get("skls"),
+			TimeText:        get("sksj"),
+			Location:        get("skdd"),
+			Campus:          get("xqmc"),
+			Capacity:        getInt("pkrs"),
+			Selected:        getInt("xkrs"),
+			Remaining:       getInt("syrs"),
+			Recommendations: []model.CourseRecommendationPublic{},
+		}
+		if _, ok := row["syrs"]; !ok && course.Capacity > 0 {
+			course.Remaining = max(0, course.Capacity-course.Selected)
+		}
+		course.Times = parseElectiveTimes(course.TimeText)
+		courses = append(courses, course)
+	}
+
+	total, err := payload.ITotalRecords.Int64()
+	if err != nil {
+		total = int64(len(courses))
+	}
+	return int(total), courses, nil
+}
+
+// parseElectiveTimes 解析选课列表中的上课时间
+// 格式: "1-16周 星期一 1-2节"，多个时间段之间以 <br>、";" 或换行分隔
+func parseElectiveTimes(raw string) []model.ClassTimeParse {
+	times := make([]model.ClassTimeParse, 0)
+	reSplit := regexp.MustCompile(`(?i)<br\s*/?>|[;；\n]`)
+	reTime := regexp.MustCompile(`^(.*?)周?\s*星期([一二三四五六日天])\s*([\d\-,，]+)节?`)
+
+	for _, part := range reSplit.Split(raw, -1) {
+		m := reTime.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			continue
+		}
+		dayName := m[2]
+		if dayName == "天" {
+			dayName = "日"
+		}
+		times = append(times, model.ClassTimeParse{
+			DayOfWeek:   slices.Index(weekdayNames, dayName) + 1,
+			PeriodArray: expandPeriodRange(m[3]),
+			Weeks:       parseWeekList(m[1]),
+		})
+	}
+	return times
+}
+
+// expandPeriodRange 展开节次，"1-4" 表示第 1 到 4 节，"01-02-03" 这类三个以上的写法视为逐节列举
+func expandPeriodRange(raw string) []int {
+	periods := parsePeriodList(raw)
+	if len(periods) != 2 || !strings.Contains(raw, "-") || periods[1] <= periods[0] {
+		return periods
+	}
+	expanded := make([]int, 0, periods[1]-periods[0]+1)
+	for p := periods[0]; p <= periods[1]; p++ {
+		expanded = append(expanded, p)
+	}
+	return expanded
+}
+
+// filterElectiveCourses 按校区和时间段做本地筛选，上游对星期节次的筛选并不总是生效
+func filterElectiveCourses(courses []model.ElectiveCourse, req model.ElectiveCatalogRequest) []model.ElectiveCourse {
+	campus := normalizeCampus(req.Campus)
+	filtered := make([]model.ElectiveCourse, 0, len(courses))
+
+	for _, c := range courses {
+		if campus != "" && !strings.Contains(normalizeCampus(c.Campus), campus) && !strings.Contains(c.Location, campus) {
+			continue
+		}
+		if (req.Day > 0 || req.Period > 0) && !matchesTimeSlot(c.Times, req.Day, req.Period) {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	return filtered
+}
+
+// normalizeCampus 统一校区名称的写法，学籍信息中为 "曲阜校区"，选课目录中可能只写 "曲阜"
+func normalizeCampus(campus string) string {
+	return strings.TrimSuffix(strings.TrimSpace(campus), "校区")
+}
+
+// matchesTimeSlot 判断课程是否有一个时间段落在指定的星期 / 节次上
+func matchesTimeSlot(times []model.ClassTimeParse, day, period int) bool {
+	for _, t := range times {
+		if day > 0 && t.DayOfWeek != day {
+			continue
+		}
+		if period > 0 && !slices.Contains(t.PeriodArray, period) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// annotateElectiveCourses 为课程附加选课推荐，同一教师的推荐排在前面
+// 推荐数据只是参考，查询失败时不影响课程目录本身
+func annotateElectiveCourses(courses []model.ElectiveCourse) {
+	names := make([]string, 0, len(courses))
+	for _, c := range courses {
+		names = append(names, c.CourseName)
+	}
+
+	recs, err := courseRecService.FindByCourseNames(names)
+	if err != nil {
+		slog.Warn("查询课程推荐失败，跳过推荐标注", "error", err)
+		return
+	}
+
+	for i := range courses {
+		list := slices.Clone(recs[courses[i].CourseName])
+		if list == nil {
+			continue
+		}
+		teacher := courses[i].Teacher
+		slices.SortStableFunc(list, func(a, b model.CourseRecommendationPublic) int {
+			aMatch := a.TeacherName != "" && strings.Contains(teacher, a.TeacherName)
+			bMatch := b.TeacherName != "" && strings.Contains(teacher, b.TeacherName)
+			switch {
+			case aMatch && !bMatch:
+				return -1
+			case !aMatch && bMatch:
+				return 1
+			}
+			return 0
+		})
+		courses[i].Recommendations = list
+	}
+}
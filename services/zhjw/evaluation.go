@@ -0,0 +1,161 @@
+package zhjw
+
+import (
+	"bytes"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// evaluationPendingHints 教务系统 "请先完成评教" 提示中的文字
+var evaluationPendingHints = []string{"请先完成评教", "未完成评教", "没有完成评教", "请先进行评价"}
+
+// isEvaluationPendingPage 判断是否为 "请先完成评教" 的提示页面
+// 只有缺少成绩表格 (#dataList) 时才检查，并且只匹配 alert 弹窗和正文中的提示，不匹配导航菜单和脚本
+func isEvaluationPendingPage(body []byte) bool {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil || doc.Find("#dataList").Length() > 0 {
+		return false
+	}
+
+	var prompts []string
+	reAlert := regexp.MustCompile(`alert\(\s*['"]([^'"]*)['"]`)
+	for _, m := range reAlert.FindAllStringSubmatch(string(body), -1) {
+		prompts = append(prompts, m[1])
+	}
+	doc.Find("script, style, nav, ul, ol, select").Remove()
+	prompts = append(prompts, doc.Find("body").Text())
+
+	for _, prompt := range prompts {
+		for _, hint := range evaluationPendingHints {
+			if strings.Contains(prompt, hint) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FetchEvaluationTasks 获取评教任务列表，只读取状态，不会代为评教
+func FetchEvaluationTasks(cookie string) (*model.EvaluationResponse, error) {
+	client := NewClient(cookie)
+
+	slog.Info("开始获取评教批次",
+		"cookie_len", len(cookie), // 不要记录完整 cookie，记录长度即可，保护隐私
+	)
+	resp, err := client.R().
+		Get("http://zhjw.qfnu.edu.cn/jsxsd/xspj/xspj_find.do")
+	if err != nil {
+		return nil, err
+	}
+
+	batches, err := parseEvaluationBatchesHtml(resp.Body())
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]model.EvaluationTask, 0)
+	for _, batch := range batches {
+		resp, err := client.R().
+			Get("http://zhjw.qfnu.edu.cn/jsxsd/xspj/" + batch.link)
+		if err != nil {
+			return nil, err
+		}
+		batchTasks, err := parseEvaluationTasksHtml(resp.Body(), batch)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, batchTasks...)
+	}
+
+	// 未提交的排在前面
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return !tasks[i].Submitted && tasks[j].Submitted
+	})
+
+	response := &model.EvaluationResponse{Tasks: tasks}
+	for _, t := range tasks {
+		if !t.Submitted {
+			response.PendingCount++
+		}
+	}
+	return response, nil
+}
+
+// evaluationBatch 一个评教批次及其课程列表链接
+type evaluationBatch struct {
+	term     string
+	name     string
+	deadline string
+	link     string // 相对于 jsxsd/xspj/ 的链接，如 xspj_list.do?pj0502id=...
+}
+
+// parseEvaluationBatchesHtml 解析评教批次列表
+// 列：序号 学年学期 评价分类 评价批次 开始时间 结束时间 操作("进入评价" 链接)
+func parseEvaluationBatchesHtml(htmlBody []byte) ([]evaluationBatch, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
+	if err != nil {
+		return nil, err
+	}
+
+	reLink := regexp.MustCompile(`xspj_list\.do\?[^'"\s]+`)
+	batches := make([]evaluationBatch, 0)
+
+	doc.Find("#dataList tr").Each(func(i int, s *goquery.Selection) {
+		tds := s.Find("td")
+		if tds.Length() < 7 {
+			return
+		}
+		rowHtml, _ := s.Html()
+		link := reLink.FindString(rowHtml)
+		if link == "" {
+			return
+		}
+		getText := func(i int) string {
+			return strings.TrimSpace(tds.Eq(i).Text())
+		}
+		batches = append(batches, evaluationBatch{
+			term:     getText(1),
+			name:     strings.TrimSpace(getText(2) + " " + getText(3)),
+			deadline: getText(5),
+			link:     strings.ReplaceAll(link, "&amp;", "&"),
+		})
+	})
+
+	return batches, nil
+}
+
+// parseEvaluationTasksHtml 解析一个批次下的评教课程列表
+// 列：序号 课程编号 课程名称 授课教师 评教类别 总评分 已评 是否提交 操作
+func parseEvaluationTasksHtml(htmlBody []byte, batch evaluationBatch) ([]model.EvaluationTask, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]model.EvaluationTask, 0)
+	doc.Find("#dataList tr").Each(func(i int, s *goquery.Selection) {
+		tds := s.Find("td")
+		if tds.Length() < 8 {
+			return
+		}
+		getText := func(i int) string {
+			return strings.TrimSpace(tds.Eq(i).Text())
+		}
+		tasks = append(tasks, model.EvaluationTask{
+			Term:       batch.term,
+			Batch:      batch.name,
+			CourseCode: getText(1),
+			CourseName: getText(2),
+			Teacher:    getText(3),
+			Deadline:   batch.deadline,
+			Submitted:  getText(7) == "是",
+		})
+	})
+
+	return tasks, nil
+}
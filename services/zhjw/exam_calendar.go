@@ -0,0 +1,140 @@
+package zhjw
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// DefaultExamAlarms 默认的考试提醒时间：提前 1 天和提前 2 小时
+var DefaultExamAlarms = []time.Duration{24 * time.Hour, 2 * time.Hour}
+
+// ParseAlarmOffsets 解析提醒时间，如 "1d,2h,30m"，为空时返回默认值
+func ParseAlarmOffsets(raw string) ([]time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return DefaultExamAlarms, nil
+	}
+
+	re := regexp.MustCompile(`^(\d+)\s*([dhm])$`)
+	var alarms []time.Duration
+	for _, part := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == '，' }) {
+		matches := re.FindStringSubmatch(strings.ToLower(strings.TrimSpace(part)))
+		if matches == nil {
+			return nil, fmt.Errorf("无法识别的提醒时间: %s", part)
+		}
+		n, _ := strconv.Atoi(matches[1])
+		switch matches[2] {
+		case "d":
+			alarms = append(alarms, time.Duration(n)*24*time.Hour)
+		case "h":
+			alarms = append(alarms, time.Duration(n)*time.Hour)
+		case "m":
+			alarms = append(alarms, time.Duration(n)*time.Minute)
+		}
+	}
+	return alarms, nil
+}
+
+// BuildExamCalendar 根据考试安排生成 iCalendar (.ics) 内容
+// 每场考试一个 VEVENT，考试时间无法解析的考试会被跳过
+func BuildExamCalendar(exams []model.ExamSchedule, alarms []time.Duration, now time.Time) []byte {
+	var b strings.Builder
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//easy-qfnu-api-go//Exam Schedule//CN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText("考试安排"))
+
+	for _, e := range exams {
+		if e.StartTime == 0 {
+			continue
+		}
+		start := time.Unix(e.StartTime, 0).UTC()
+		end := time.Unix(e.EndTime, 0).UTC()
+
+		description := []string{
+			"课程编号：" + e.CourseId,
+			"授课教师：" + e.Instructor,
+			"考场：" + e.ExamRoom,
+			"座位号：" + e.SeatNumber,
+			"准考证号：" + e.AdmissionNo,
+		}
+		if e.Campus != "" {
+			description = append(description, "校区："+e.Campus)
+		}
+		if e.Remarks != "" {
+			description = append(description, "备注："+e.Remarks)
+		}
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, fmt.Sprintf("UID:exam-%s-%d@easy-qfnu-api-go", e.CourseId, e.StartTime))
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		writeICSLine(&b, "DTSTART:"+start.Format("20060102T150405Z"))
+		writeICSLine(&b, "DTEND:"+end.Format("20060102T150405Z"))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText("考试："+e.CourseName))
+		writeICSLine(&b, "LOCATION:"+escapeICSText(e.ExamRoom))
+		writeICSLine(&b, "DESCRIPTION:"+escapeICSText(strings.Join(description, "\n")))
+
+		for _, alarm := range alarms {
+			writeICSLine(&b, "BEGIN:VALARM")
+			writeICSLine(&b, "ACTION:DISPLAY")
+			writeICSLine(&b, "TRIGGER:"+formatICSDuration(alarm))
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(e.CourseName+" 考试即将开始，考场："+e.ExamRoom))
+			writeICSLine(&b, "END:VALARM")
+		}
+
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// formatICSDuration 把提前量格式化为 TRIGGER 使用的负向时长，如 -P1D、-PT2H、-PT30M
+func formatICSDuration(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	if minutes%(24*60) == 0 && minutes > 0 {
+		return fmt.Sprintf("-P%dD", minutes/(24*60))
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("-PT%dH", minutes/60)
+	}
+	return fmt.Sprintf("-PT%dM", minutes)
+}
+
+// escapeICSText 按 RFC 5545 转义文本中的特殊字符
+func escapeICSText(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// writeICSLine 写入一行内容，超过 75 字节时按 RFC 5545 折行 (不拆开多字节字符)
+func writeICSLine(b *strings.Builder, line string) {
+	const limit = 75
+	width := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1 // 续行开头的空格也计入长度
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+}
@@ -3,10 +3,15 @@ package zhjw
 import (
 	"bytes"
 	"log/slog"
+	"math"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/W1ndys/easy-qfnu-api-go/model"
+	calendarService "github.com/W1ndys/easy-qfnu-api-go/services/calendar"
 )
 
 // FetchExamSchedules 抓取并解析成绩，返回包含统计信息的响应
@@ -42,9 +47,48 @@ func FetchExamSchedules(cookie string, term string) ([]model.ExamSchedule, error
 		return nil, err
 	}
 
+	// 计算倒计时和状态，并按考试时间排序
+	annotateExamSchedules(examSchedules, time.Now())
 	return examSchedules, nil
 }
 
+// annotateExamSchedules 计算每场考试的倒计时和状态，并按开始时间升序排序
+// 考试时间无法解析的排在最后，保持原有顺序
+func annotateExamSchedules(schedules []model.ExamSchedule, now time.Time) {
+	today := calendarService.TruncateToDay(now)
+
+	for i := range schedules {
+		es := &schedules[i]
+		start, end, ok := parseExamTime(es.ExamTime)
+		if !ok {
+			es.Status = model.ExamStatusUnknown
+			continue
+		}
+
+		es.StartTime = start.Unix()
+		es.EndTime = end.Unix()
+		days := int(math.Round(calendarService.TruncateToDay(start).Sub(today).Hours() / 24))
+		es.DaysRemaining = &days
+
+		switch {
+		case now.Before(start):
+			es.Status = model.ExamStatusUpcoming
+		case now.Before(end):
+			es.Status = model.ExamStatusOngoing
+		default:
+			es.Status = model.ExamStatusFinished
+		}
+	}
+
+	sort.SliceStable(schedules, func(i, j int) bool {
+		a, b := schedules[i].StartTime, schedules[j].StartTime
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+}
+
 // parseExamSchedulesHtml 解析考试安排 HTML
 func parseExamSchedulesHtml(htmlBody []byte) ([]model.ExamSchedule, error) {
 	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
@@ -91,3 +135,28 @@ func parseExamSchedulesHtml(htmlBody []byte) ([]model.ExamSchedule, error) {
 
 	return schedules, nil
 }
+
+// parseExamTime 解析考试时间，如 "2026-01-06 08:30~10:30"
+// 结束时间跨天的写法 (如 "2026-01-06 08:30~2026-01-06 10:30") 同样支持
+func parseExamTime(raw string) (start time.Time, end time.Time, ok bool) {
+	re := regexp.MustCompile(`(\d{4}-\d{1,2}-\d{1,2})\s+(\d{1,2}:\d{2})\s*[~～\-至]+\s*(?:(\d{4}-\d{1,2}-\d{1,2})\s+)?(\d{1,2}:\d{2})`)
+	matches := re.FindStringSubmatch(raw)
+	if len(matches) != 5 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	endDate := matches[3]
+	if endDate == "" {
+		endDate = matches[1]
+	}
+
+	start, err := time.ParseInLocation("2006-1-2 15:04", matches[1]+" "+matches[2], time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	end, err = time.ParseInLocation("2006-1-2 15:04", endDate+" "+matches[4], time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
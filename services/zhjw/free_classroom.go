@@ -0,0 +1,138 @@
+package zhjw
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// FetchFreeClassrooms 查询指定日期、节次范围内的空闲教室
+func FetchFreeClassrooms(cookie string, req model.FreeClassroomRequest) (*model.FreeClassroomResponse, error) {
+	date, err := parseScheduleDate(req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("日期格式错误: %w", err)
+	}
+
+	endPeriod := req.EndPeriod
+	if endPeriod == 0 {
+		endPeriod = req.StartPeriod
+	}
+	if req.StartPeriod < 1 || endPeriod < req.StartPeriod {
+		return nil, fmt.Errorf("节次范围错误: %d-%d", req.StartPeriod, endPeriod)
+	}
+
+	term := strings.TrimSpace(req.Term)
+	if term == "" {
+		term = termOfDate(date)
+	}
+
+	// 教室占用查询按 周次 + 星期 查询，需要先把日期换算成教学周
+	week, dayOfWeek, err := ResolveTeachingWeek(cookie, date)
+	if err != nil {
+		return nil, err
+	}
+
+	// 使用工厂函数创建 Client (自带检查功能)
+	client := NewClient(cookie)
+
+	targetURL := "http://zhjw.qfnu.edu.cn/jsxsd/kbxx/jsjy_query2"
+	formData := map[string]string{
+		"typewhere": "jszq",                               // 按教室占用情况查询
+		"xnxqh":     term,                                 // 学年学期
+		"xqbh":      strings.TrimSpace(req.Campus),        // 校区
+		"jxlbh":     strings.TrimSpace(req.Building),      // 教学楼
+		"zc":        strconv.Itoa(week),                   // 开始周次
+		"zc2":       strconv.Itoa(week),                   // 结束周次
+		"xq":        strconv.Itoa(dayOfWeek),              // 开始星期
+		"xq2":       strconv.Itoa(dayOfWeek),              // 结束星期
+		"jc1":       fmt.Sprintf("%02d", req.StartPeriod), // 开始节次
+		"jc2":       fmt.Sprintf("%02d", endPeriod),       // 结束节次
+	}
+
+	// 记录重要的业务行为
+	slog.Info("开始查询空教室",
+		"date", req.Date,
+		"term", term,
+		"week", week,
+		"campus", req.Campus,
+		"building", req.Building,
+		"cookie_len", len(cookie), // 不要记录完整 cookie，记录长度即可，保护隐私
+	)
+	// 发起 POST 请求
+	resp, err := client.R().
+		SetFormData(formData).
+		Post(targetURL)
+
+	// 错误处理
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := parseFreeClassroomsHtml(resp.Body())
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.FreeClassroomResponse{
+		Date:        date.Format("2006-01-02"),
+		Term:        term,
+		Week:        week,
+		DayOfWeek:   dayOfWeek,
+		StartPeriod: req.StartPeriod,
+		EndPeriod:   endPeriod,
+		Rooms:       rooms,
+	}, nil
+}
+
+// parseFreeClassroomsHtml 解析教室占用情况 HTML
+// 表格每行是一间教室：第一列为教室名称 (含座位数)，后续每列对应一个节次，有内容即表示被占用
+func parseFreeClassroomsHtml(htmlBody []byte) ([]model.FreeClassroom, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
+	if err != nil {
+		return nil, err
+	}
+
+	rooms := make([]model.FreeClassroom, 0)
+	reCapacity := regexp.MustCompile(`[\(（](?:\d+/)?(\d+)[\)）]`)
+
+	doc.Find("#dataTable tr").Each(func(i int, s *goquery.Selection) {
+		tds := s.Find("td")
+		// 表头行使用 th，或者只有一列的提示行
+		if tds.Length() < 2 {
+			return
+		}
+
+		rawName := strings.TrimSpace(tds.First().Text())
+		if rawName == "" {
+			return
+		}
+
+		// 任意一个节次有内容都视为被占用
+		occupied := false
+		tds.Slice(1, tds.Length()).EachWithBreak(func(_ int, cell *goquery.Selection) bool {
+			if strings.TrimSpace(strings.ReplaceAll(cell.Text(), " ", "")) != "" {
+				occupied = true
+				return false
+			}
+			return true
+		})
+		if occupied {
+			return
+		}
+
+		room := model.FreeClassroom{Name: rawName}
+		if matches := reCapacity.FindStringSubmatchIndex(rawName); matches != nil {
+			room.Name = strings.TrimSpace(rawName[:matches[0]])
+			room.Capacity, _ = strconv.Atoi(rawName[matches[2]:matches[3]])
+		}
+		rooms = append(rooms, room)
+	})
+
+	return rooms, nil
+}
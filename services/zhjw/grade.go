@@ -47,6 +47,11 @@ func FetchGrades(cookie string, term string, courseType string, courseName strin
 		return nil, err // 遇到错误立刻返回
 	}
 
+	// 评教未完成时教务系统返回的是提示页面而不是成绩表格
+	if isEvaluationPendingPage(resp.Body()) {
+		return nil, ErrEvaluationPending
+	}
+
 	// 解析 HTML (调用内部私有函数)
 	grades, err := parseGradesHtml(resp.Body())
 	if err != nil {
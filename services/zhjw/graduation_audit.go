@@ -0,0 +1,191 @@
+package zhjw
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// gradeRecord 一门课程的成绩汇总 (可能有多次考试记录)
+type gradeRecord struct {
+	passed bool // 是否有及格记录
+	failed bool // 是否有不及格记录
+}
+
+// gradeIndex 按课程编号和课程名称索引的成绩
+type gradeIndex struct {
+	byCode map[string]*gradeRecord
+	byName map[string]*gradeRecord
+}
+
+// newGradeIndex 建立成绩索引
+// 没有课程编号的成绩只按课程名称索引，避免它们共用同一条空编号记录
+// 按名称索引的记录合并所有同名成绩，同名但编号不同的课程不会互相覆盖
+func newGradeIndex(grades []model.Grade) gradeIndex {
+	idx := gradeIndex{byCode: map[string]*gradeRecord{}, byName: map[string]*gradeRecord{}}
+	mark := func(rec *gradeRecord, passed bool) {
+		if passed {
+			rec.passed = true
+		} else {
+			rec.failed = true
+		}
+	}
+	for _, g := range grades {
+		passed := isPassed(g.Score)
+
+		nameRec := idx.byName[g.CourseName]
+		if nameRec == nil {
+			nameRec = &gradeRecord{}
+			idx.byName[g.CourseName] = nameRec
+		}
+		mark(nameRec, passed)
+
+		if g.CourseCode == "" {
+			continue
+		}
+		codeRec := idx.byCode[g.CourseCode]
+		if codeRec == nil {
+			codeRec = &gradeRecord{}
+			idx.byCode[g.CourseCode] = codeRec
+		}
+		mark(codeRec, passed)
+	}
+	return idx
+}
+
+// lookup 先按课程编号查找，找不到再按课程名称查找
+func (idx gradeIndex) lookup(code, name string) *gradeRecord {
+	if rec, ok := idx.byCode[code]; ok && code != "" {
+		return rec
+	}
+	return idx.byName[name]
+}
+
+// coursePassed 判断培养方案中的课程是否已通过：有及格成绩，或培养方案标记为已修 / 免修
+func (idx gradeIndex) coursePassed(course model.CoursePlanInfo) bool {
+	if rec := idx.lookup(course.CourseCode, course.CourseName); rec != nil && rec.passed {
+		return true
+	}
+	return course.StatusCode == model.CourseStatusCompleted || course.StatusCode == model.CourseStatusExempted
+}
+
+// selectionIndex 本学期选课结果，按课程编号和课程名称索引
+type selectionIndex map[string]bool
+
+// newSelectionIndex 建立选课结果索引
+func newSelectionIndex(selections []model.SelectionResult) selectionIndex {
+	idx := selectionIndex{}
+	for _, s := range selections {
+		idx[s.CourseId] = true
+		idx[s.CourseName] = true
+	}
+	return idx
+}
+
+// has 判断课程是否已选
+func (idx selectionIndex) has(code, name string) bool {
+	return (code != "" && idx[code]) || idx[name]
+}
+
+// AuditGraduation 结合培养方案、成绩和本学期选课结果进行毕业审核
+func AuditGraduation(cookie string, term string) (*model.GraduationAuditResponse, error) {
+	term = currentTermOr(term)
+
+	plan, err := FetchCoursePlan(cookie)
+	if err != nil {
+		return nil, err
+	}
+	grades, err := FetchGrades(cookie, "", "", "", "all")
+	if err != nil {
+		return nil, err
+	}
+	// 选课结果只用于判断 "选完即可修满"，查不到时按未选课处理
+	selections, err := FetchSelectionResults(cookie, term)
+	if err != nil && !errors.Is(err, ErrResourceNotFound) {
+		return nil, err
+	}
+
+	currentSemester := semesterIndexOf(entryYearOf(grades.Grades), term)
+	return auditGraduation(plan, grades.Grades, selections, currentSemester), nil
+}
+
+// auditGraduation 毕业审核的核心计算逻辑
+func auditGraduation(plan *model.CoursePlanResponse, grades []model.Grade, selections []model.SelectionResult, currentSemester int) *model.GraduationAuditResponse {
+	gradeIdx := newGradeIndex(grades)
+	selectionIdx := newSelectionIndex(selections)
+
+	response := &model.GraduationAuditResponse{
+		Verdict:         model.AuditVerdictOnTrack,
+		Reasons:         []string{},
+		CurrentSemester: currentSemester,
+		Groups:          []model.GroupAudit{},
+	}
+
+	for _, group := range plan.Groups {
+		audit := model.GroupAudit{
+			GroupName:        group.GroupName,
+			RequiredCredits:  group.RequiredCredits,
+			EarnedCredits:    group.EarnedCredits,
+			RemainingCredits: round2(math.Max(0, group.RequiredCredits-group.EarnedCredits)),
+			UnpassedRequired: []model.UnpassedCourse{},
+		}
+		audit.Satisfied = audit.RemainingCredits == 0
+
+		for _, course := range group.Courses {
+			if gradeIdx.coursePassed(course) {
+				continue
+			}
+
+			selected := selectionIdx.has(course.CourseCode, course.CourseName)
+			if selected {
+				audit.SelectedCredits += course.Credits
+			}
+
+			// 已修满的选课组中未修的课程只是可选项，不再视为必修
+			if course.CourseAttr != "必修" || audit.Satisfied {
+				continue
+			}
+			rec := gradeIdx.lookup(course.CourseCode, course.CourseName)
+			termIndex := planTermIndex(course.Term)
+			unpassed := model.UnpassedCourse{
+				CourseCode: course.CourseCode,
+				CourseName: course.CourseName,
+				Credits:    course.Credits,
+				Term:       course.Term,
+				Failed:     rec != nil && rec.failed,
+				Selected:   selected,
+				Overdue:    currentSemester > 0 && termIndex > 0 && termIndex < currentSemester,
+			}
+			audit.UnpassedRequired = append(audit.UnpassedRequired, unpassed)
+
+			// 已过开设学期或挂科、且本学期没有重修的必修课视为风险
+			if !selected && (unpassed.Overdue || unpassed.Failed) {
+				response.Verdict = model.AuditVerdictAtRisk
+				response.Reasons = append(response.Reasons,
+					fmt.Sprintf("必修课「%s」尚未通过且本学期未选", course.CourseName))
+			}
+		}
+
+		audit.SelectedCredits = round2(audit.SelectedCredits)
+		audit.CompletedBySelection = !audit.Satisfied && audit.SelectedCredits >= audit.RemainingCredits
+
+		// 已到最后一学年仍有选课组学分不足，且本学期选课也补不上
+		if !audit.Satisfied && !audit.CompletedBySelection && currentSemester >= 7 {
+			response.Verdict = model.AuditVerdictAtRisk
+			response.Reasons = append(response.Reasons,
+				fmt.Sprintf("「%s」尚差 %.1f 学分，本学期选课后仍不足", group.GroupName, audit.RemainingCredits-audit.SelectedCredits))
+		}
+
+		response.RequiredCredits += group.RequiredCredits
+		response.EarnedCredits += math.Min(group.EarnedCredits, group.RequiredCredits)
+		response.RemainingCredits += audit.RemainingCredits
+		response.Groups = append(response.Groups, audit)
+	}
+
+	response.RequiredCredits = round2(response.RequiredCredits)
+	response.EarnedCredits = round2(response.EarnedCredits)
+	response.RemainingCredits = round2(response.RemainingCredits)
+	return response
+}
@@ -0,0 +1,126 @@
+package zhjw
+
+import (
+	"bytes"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// FetchLevelExamScores 抓取并解析等级考试成绩 (四六级、计算机等级考试等)
+func FetchLevelExamScores(cookie string) ([]model.LevelExamScore, error) {
+
+	// 使用工厂函数创建 Client (自带检查功能)
+	client := NewClient(cookie)
+
+	targetURL := "http://zhjw.qfnu.edu.cn/jsxsd/kscj/djkscj_list"
+
+	// 记录重要的业务行为
+	slog.Info("开始抓取等级考试成绩",
+		"cookie_len", len(cookie), // 不要记录完整 cookie，记录长度即可，保护隐私
+	)
+	// 发起 POST 请求
+	resp, err := client.R().
+		Post(targetURL)
+
+	// 错误处理
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLevelExamScoresHtml(resp.Body())
+}
+
+// parseLevelExamScoresHtml 解析等级考试成绩 HTML
+// 表头有两层：第一层如 "笔试成绩"(colspan=2)，第二层为 "分数成绩"、"等级成绩"，
+// 这里先把表头展开成一维的列名 (如 "笔试成绩-分数成绩")，再按列名取值
+func parseLevelExamScoresHtml(htmlBody []byte) ([]model.LevelExamScore, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
+	if err != nil {
+		return nil, err
+	}
+
+	columns := flattenHeader(doc.Find("#dataList tr:has(th)"))
+	scores := make([]model.LevelExamScore, 0)
+
+	doc.Find("#dataList tr").Each(func(i int, s *goquery.Selection) {
+		tds := s.Find("td")
+		// 跳过表头和 "未查询到数据" 的提示行
+		if tds.Length() < 2 {
+			return
+		}
+
+		score := model.LevelExamScore{SubScores: []model.LevelExamSubScore{}}
+		tds.Each(func(j int, td *goquery.Selection) {
+			if j >= len(columns) {
+				return
+			}
+			name := columns[j]
+			val := strings.TrimSpace(td.Text())
+
+			switch {
+			case name == "序号":
+			case strings.Contains(name, "课程") || strings.Contains(name, "名称") || strings.Contains(name, "科目"):
+				score.ExamName = val
+			case strings.Contains(name, "时间") || strings.Contains(name, "日期"):
+				score.ExamDate = val
+			case strings.HasPrefix(name, "总成绩") || name == "总分":
+				// 总成绩可能同时有分数和等级两列，优先取分数
+				if score.TotalScore == "" || isNumeric(val) {
+					score.TotalScore = val
+				}
+			case val != "":
+				score.SubScores = append(score.SubScores, model.LevelExamSubScore{Name: name, Score: val})
+			}
+		})
+
+		if score.ExamName != "" {
+			scores = append(scores, score)
+		}
+	})
+
+	return scores, nil
+}
+
+// flattenHeader 把一层或两层的表头展开为与数据列一一对应的列名
+func flattenHeader(rows *goquery.Selection) []string {
+	if rows.Length() == 0 {
+		return nil
+	}
+
+	var subNames []string
+	if rows.Length() > 1 {
+		rows.Eq(1).Find("th").Each(func(_ int, th *goquery.Selection) {
+			subNames = append(subNames, strings.TrimSpace(th.Text()))
+		})
+	}
+
+	var columns []string
+	subIndex := 0
+	rows.First().Find("th").Each(func(_ int, th *goquery.Selection) {
+		name := strings.TrimSpace(th.Text())
+		colspan, _ := strconv.Atoi(th.AttrOr("colspan", "1"))
+		if colspan <= 1 {
+			columns = append(columns, name)
+			return
+		}
+		for k := 0; k < colspan; k++ {
+			sub := ""
+			if subIndex < len(subNames) {
+				sub = subNames[subIndex]
+			}
+			subIndex++
+			columns = append(columns, name+"-"+sub)
+		}
+	})
+	return columns
+}
+
+// isNumeric 判断字符串是否为数字
+func isNumeric(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
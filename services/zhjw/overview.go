@@ -0,0 +1,158 @@
+package zhjw
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/W1ndys/easy-qfnu-api-go/internal/config"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// OverviewResult 个人概览各部分的结果，每部分独立成功或失败
+// 错误码和提示语由接口层根据错误类型决定
+type OverviewResult struct {
+	GPA          *model.OverviewGPA
+	GPAErr       error
+	Today        *model.OverviewToday
+	TodayErr     error
+	Exams        *[]model.ExamSchedule
+	ExamsErr     error
+	Selection    *model.SelectionResultsResponse
+	SelectionErr error
+}
+
+// FetchOverview 并发获取绩点、今日课程、考试安排和选课结果
+// 各部分独立成功或失败；只有全部部分都因 Cookie 失效而失败时才返回 ErrCookieExpired
+func FetchOverview(cookie string) (*OverviewResult, error) {
+	var result OverviewResult
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		result.GPA, result.GPAErr = overviewGPA(cookie)
+	}()
+	go func() {
+		defer wg.Done()
+		result.Today, result.TodayErr = overviewToday(cookie, now)
+	}()
+	go func() {
+		defer wg.Done()
+		result.Exams, result.ExamsErr = overviewExams(cookie)
+	}()
+	go func() {
+		defer wg.Done()
+		result.Selection, result.SelectionErr = overviewSelection(cookie)
+	}()
+	wg.Wait()
+
+	if errors.Is(result.GPAErr, ErrCookieExpired) &&
+		errors.Is(result.TodayErr, ErrCookieExpired) &&
+		errors.Is(result.ExamsErr, ErrCookieExpired) &&
+		errors.Is(result.SelectionErr, ErrCookieExpired) {
+		return nil, ErrCookieExpired
+	}
+	return &result, nil
+}
+
+// overviewGPA 获取总体绩点和最近一个学期的绩点
+func overviewGPA(cookie string) (*model.OverviewGPA, error) {
+	grades, err := FetchGrades(cookie, "", "", "", "all")
+	if errors.Is(err, ErrResourceNotFound) {
+		return &model.OverviewGPA{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	gpa := &model.OverviewGPA{Total: grades.TotalStat}
+	for i := range grades.SemesterStats {
+		stat := grades.SemesterStats[i]
+		if gpa.Latest == nil || stat.Semester > gpa.Latest.Semester {
+			gpa.Latest = &stat
+		}
+	}
+	return gpa, nil
+}
+
+// overviewToday 获取今天的课程，调休补课日按校历指定的星期取课，放假日没有课程
+func overviewToday(cookie string, now time.Time) (*model.OverviewToday, error) {
+	date := now.Format("2006-01-02")
+	schedule, err := FetchClassSchedules(cookie, date)
+	if errors.Is(err, ErrResourceNotFound) {
+		schedule, err = &model.ClassScheduleResponse{}, nil
+		fillCalendarDay(schedule, date)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	today := &model.OverviewToday{
+		Date:        date,
+		CurrentWeek: schedule.CurrentWeek,
+		DayOfWeek:   (int(now.Weekday())+6)%7 + 1,
+		InTerm:      schedule.InTerm,
+		Courses:     []model.ClassSchedules{},
+		Calendar:    schedule.Calendar,
+	}
+
+	dayOfWeek := today.DayOfWeek
+	courses := schedule.Courses
+	if cal := schedule.Calendar; cal != nil {
+		if cal.DayType == model.CalendarDayHoliday {
+			return today, nil
+		}
+		// 调休补课日按被调换那天的课表上课，被调换的那天不在本周时 (如国庆前后) 另取那一周的课程表
+		if cal.DayType == model.CalendarDayMakeup && cal.ScheduleDayOfWeek > 0 {
+			dayOfWeek = cal.ScheduleDayOfWeek
+			if cal.ScheduleWeek != cal.Week && cal.FollowDate != "" {
+				followed, err := FetchClassSchedules(cookie, cal.FollowDate)
+				if err != nil {
+					return nil, err
+				}
+				courses = followed.Courses
+			}
+		}
+	}
+
+	for _, course := range courses {
+		if course.TimeParsed.DayOfWeek == dayOfWeek {
+			today.Courses = append(today.Courses, course)
+		}
+	}
+	return today, nil
+}
+
+// overviewExams 获取未结束的考试
+func overviewExams(cookie string) (*[]model.ExamSchedule, error) {
+	exams, err := FetchExamSchedules(cookie, "")
+	if errors.Is(err, ErrResourceNotFound) {
+		exams, err = []model.ExamSchedule{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]model.ExamSchedule, 0, len(exams))
+	for _, exam := range exams {
+		if exam.Status != model.ExamStatusFinished {
+			pending = append(pending, exam)
+		}
+	}
+	return &pending, nil
+}
+
+// overviewSelection 获取本学期选课结果及学分统计 (不匹配课表时段，避免额外请求)
+func overviewSelection(cookie string) (*model.SelectionResultsResponse, error) {
+	results, err := FetchSelectionResults(cookie, "")
+	if errors.Is(err, ErrResourceNotFound) {
+		results, err = []model.SelectionResult{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	minCredits, maxCredits := config.GetSelectionCreditLimits()
+	return summarizeSelectionResults(results, nil, minCredits, maxCredits), nil
+}
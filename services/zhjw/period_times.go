@@ -0,0 +1,47 @@
+package zhjw
+
+import (
+	"time"
+
+	calendarService "github.com/W1ndys/easy-qfnu-api-go/services/calendar"
+)
+
+// periodTimes 每个小节的上下课时间 (距 0 点的分钟数)
+// 教务系统页面上不提供作息时间，这里按学校公布的作息表写死
+var periodTimes = map[int][2]int{
+	1:  {8*60 + 0, 8*60 + 45},
+	2:  {8*60 + 55, 9*60 + 40},
+	3:  {10*60 + 0, 10*60 + 45},
+	4:  {10*60 + 55, 11*60 + 40},
+	5:  {14*60 + 0, 14*60 + 45},
+	6:  {14*60 + 55, 15*60 + 40},
+	7:  {16*60 + 0, 16*60 + 45},
+	8:  {16*60 + 55, 17*60 + 40},
+	9:  {19*60 + 0, 19*60 + 45},
+	10: {19*60 + 55, 20*60 + 40},
+	11: {20*60 + 50, 21*60 + 35},
+	12: {21*60 + 45, 22*60 + 30},
+}
+
+// periodSpan 返回一组节次在某天的开始和结束时间，节次未知时 ok 为 false
+func periodSpan(day time.Time, periods []int) (start time.Time, end time.Time, ok bool) {
+	first, last := -1, -1
+	for _, p := range periods {
+		span, exists := periodTimes[p]
+		if !exists {
+			continue
+		}
+		if first < 0 || span[0] < first {
+			first = span[0]
+		}
+		if last < 0 || span[1] > last {
+			last = span[1]
+		}
+	}
+	if first < 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	day = calendarService.TruncateToDay(day)
+	return day.Add(time.Duration(first) * time.Minute), day.Add(time.Duration(last) * time.Minute), true
+}
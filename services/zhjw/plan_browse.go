@@ -0,0 +1,269 @@
+package zhjw
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+const planQueryURL = "http://zhjw.qfnu.edu.cn/jsxsd/pyfa/pyfa_query"
+
+// FetchPlanOptions 获取培养方案查询页面可选的年级和专业
+func FetchPlanOptions(cookie string) (*model.PlanOptionsResponse, error) {
+	client := NewClient(cookie)
+
+	slog.Info("开始获取培养方案查询选项",
+		"cookie_len", len(cookie), // 不要记录完整 cookie，记录长度即可，保护隐私
+	)
+	resp, err := client.R().
+		Get(planQueryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(resp.Body()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PlanOptionsResponse{
+		Grades: parseSelectOptions(doc.Find(`select[name="nj"] option`)),
+		Majors: parseSelectOptions(doc.Find(`select[name="zyh"] option`)),
+	}, nil
+}
+
+// parseSelectOptions 解析下拉框选项，跳过 "--请选择--" 这类空值选项
+func parseSelectOptions(options *goquery.Selection) []model.PlanOption {
+	result := make([]model.PlanOption, 0)
+	options.Each(func(_ int, o *goquery.Selection) {
+		value := strings.TrimSpace(o.AttrOr("value", ""))
+		if value == "" {
+			return
+		}
+		result = append(result, model.PlanOption{Value: value, Label: strings.TrimSpace(o.Text())})
+	})
+	return result
+}
+
+// SearchPlans 按年级和专业查询培养方案列表
+// 年级和专业都为空时，使用学籍信息中的年级查询，并优先返回本专业的培养方案
+func SearchPlans(cookie string, req model.PlanSearchRequest) ([]model.PlanSummary, error) {
+	var ownMajor string
+	if strings.TrimSpace(req.Grade) == "" && strings.TrimSpace(req.Major) == "" {
+		if basics, err := loadProfileBasics(cookie); err == nil {
+			req.Grade = basics.Grade
+			ownMajor = basics.Major
+		} else if errors.Is(err, ErrCookieExpired) {
+			return nil, err
+		}
+	}
+
+	client := NewClient(cookie)
+
+	formData := map[string]string{
+		"nj":  strings.TrimSpace(req.Grade), // 年级
+		"zyh": strings.TrimSpace(req.Major), // 专业
+	}
+
+	slog.Info("开始查询培养方案列表",
+		"grade", req.Grade,
+		"major", req.Major,
+		"cookie_len", len(cookie), // 不要记录完整 cookie，记录长度即可，保护隐私
+	)
+	resp, err := client.R().
+		SetFormData(formData).
+		Post(planQueryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := parsePlanListHtml(resp.Body())
+	if err != nil {
+		return nil, err
+	}
+	if ownMajor != "" {
+		slices.SortStableFunc(plans, func(a, b model.PlanSummary) int {
+			aOwn, bOwn := a.Major == ownMajor, b.Major == ownMajor
+			switch {
+			case aOwn && !bOwn:
+				return -1
+			case !aOwn && bOwn:
+				return 1
+			}
+			return 0
+		})
+	}
+	return plans, nil
+}
+
+// parsePlanListHtml 解析培养方案列表 HTML
+// 列：序号 年级 院系 专业 培养方案名称 操作(查看链接中带 pyfaid)
+func parsePlanListHtml(htmlBody []byte) ([]model.PlanSummary, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
+	if err != nil {
+		return nil, err
+	}
+
+	rePlanID := regexp.MustCompile(`pyfaid=([\w\-]+)`)
+	plans := make([]model.PlanSummary, 0)
+
+	doc.Find("#dataList tr").Each(func(i int, s *goquery.Selection) {
+		tds := s.Find("td")
+		if tds.Length() < 6 {
+			return
+		}
+
+		rowHtml, _ := s.Html()
+		matches := rePlanID.FindStringSubmatch(rowHtml)
+		if matches == nil {
+			return
+		}
+
+		getText := func(i int) string {
+			return strings.TrimSpace(tds.Eq(i).Text())
+		}
+		plans = append(plans, model.PlanSummary{
+			PlanID:  matches[1],
+			Grade:   getText(1),
+			College: getText(2),
+			Major:   getText(3),
+			Name:    getText(4),
+		})
+	})
+
+	return plans, nil
+}
+
+// FetchPlanByID 获取指定的培养方案详情
+func FetchPlanByID(cookie string, planID string) (*model.CoursePlanResponse, error) {
+	client := NewClient(cookie)
+
+	slog.Info("开始获取培养方案详情",
+		"plan_id", planID,
+		"cookie_len", len(cookie), // 不要记录完整 cookie，记录长度即可，保护隐私
+	)
+	resp, err := client.R().
+		SetQueryParam("pyfaid", strings.TrimSpace(planID)).
+		Get("http://zhjw.qfnu.edu.cn/jsxsd/pyfa/pyfa_query_xx")
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(resp.Body()))
+	if err != nil {
+		return nil, fmt.Errorf("parse html failed: %w", err)
+	}
+
+	return parseCoursePlanDoc(doc), nil
+}
+
+// ComparePlan 对比当前修读记录与目标培养方案，给出可认定的学分 (转专业 / 辅修参考)
+func ComparePlan(cookie string, planID string) (*model.PlanCompareResponse, error) {
+	target, err := FetchPlanByID(cookie, planID)
+	if err != nil {
+		return nil, err
+	}
+	grades, err := FetchGrades(cookie, "", "", "", "all")
+	if err != nil {
+		return nil, err
+	}
+
+	response := comparePlan(target, grades.Grades)
+	response.PlanID = planID
+	return response, nil
+}
+
+// comparePlan 培养方案对比的核心计算逻辑
+func comparePlan(target *model.CoursePlanResponse, grades []model.Grade) *model.PlanCompareResponse {
+	gradeIdx := newGradeIndex(grades)
+
+	response := &model.PlanCompareResponse{
+		Groups:    []model.PlanCompareGroup{},
+		Uncounted: []model.PlanCompareCourse{},
+	}
+
+	// 每门已修课程只认定一次，记录已经被认定的课程编号和名称
+	// 没有课程编号的课程只按名称记录，避免一门无编号课程挡住其他无编号课程
+	counted := make(map[string]bool)
+	isCounted := func(code, name string) bool {
+		return (code != "" && counted[code]) || counted[name]
+	}
+
+	for _, group := range target.Groups {
+		result := model.PlanCompareGroup{
+			GroupName:       group.GroupName,
+			RequiredCredits: group.RequiredCredits,
+			CarriedCourses:  []model.PlanCompareCourse{},
+			MissingRequired: []model.PlanCompareCourse{},
+		}
+
+		var carried float64
+		for _, course := range group.Courses {
+			item := model.PlanCompareCourse{
+				CourseCode: course.CourseCode,
+				CourseName: course.CourseName,
+				Credits:    course.Credits,
+				CourseAttr: course.CourseAttr,
+			}
+
+			rec := gradeIdx.lookup(course.CourseCode, course.CourseName)
+			if rec != nil && rec.passed && !isCounted(course.CourseCode, course.CourseName) {
+				if course.CourseCode != "" {
+					counted[course.CourseCode] = true
+				}
+				counted[course.CourseName] = true
+				carried += course.Credits
+				result.CarriedCourses = append(result.CarriedCourses, item)
+				continue
+			}
+			if course.CourseAttr == "必修" {
+				result.MissingRequired = append(result.MissingRequired, item)
+			}
+		}
+
+		result.CarriedCredits = round2(math.Min(carried, group.RequiredCredits))
+		result.RemainingCredits = round2(math.Max(0, group.RequiredCredits-result.CarriedCredits))
+		// 学分已够的组里未修的课程只是可选项
+		if result.RemainingCredits == 0 {
+			result.MissingRequired = []model.PlanCompareCourse{}
+		}
+
+		response.RequiredCredits += group.RequiredCredits
+		response.CarriedCredits += result.CarriedCredits
+		response.RemainingCredits += result.RemainingCredits
+		response.Groups = append(response.Groups, result)
+	}
+
+	// 已修但目标方案中没有的课程，同一门课重修多次只列一次 (有编号按编号，没有编号按名称)
+	seen := make(map[string]bool)
+	for _, g := range grades {
+		key := g.CourseCode
+		if key == "" {
+			key = "name:" + g.CourseName
+		}
+		if isCounted(g.CourseCode, g.CourseName) || seen[key] || !isPassed(g.Score) {
+			continue
+		}
+		seen[key] = true
+		credits, _ := strconv.ParseFloat(g.Credit, 64)
+		response.Uncounted = append(response.Uncounted, model.PlanCompareCourse{
+			CourseCode: g.CourseCode,
+			CourseName: g.CourseName,
+			Credits:    credits,
+		})
+	}
+
+	response.RequiredCredits = round2(response.RequiredCredits)
+	response.CarriedCredits = round2(response.CarriedCredits)
+	response.RemainingCredits = round2(response.RemainingCredits)
+	return response
+}
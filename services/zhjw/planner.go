@@ -0,0 +1,268 @@
+package zhjw
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// 规划搜索的上限，避免候选过多时组合爆炸
+// 搜索节点包括因冲突提前结束的分支，候选课程和每门课的教学班数量在搜索前检查
+const (
+	plannerMaxExplored   = 20000
+	plannerMaxCourses    = 15
+	plannerMaxOptions    = 30
+	plannerDefaultResult = 10
+	plannerMaxResult     = 50
+)
+
+// 各项偏好的扣分权重
+const (
+	penaltyEarlyClass    = 1.0
+	penaltyFreeDayClass  = 2.0
+	penaltyCampusHop     = 1.5
+	earlyClassLastPeriod = 2 // 第 1-2 节视为早课
+)
+
+// plannerSlot 参与冲突检测和偏好计算的一个上课时间段
+type plannerSlot struct {
+	day      int
+	periods  []int
+	weeks    []int // 为空表示全部周次
+	location string
+}
+
+// plannerCourse 同名候选合并成的一门课程
+type plannerCourse struct {
+	name     string
+	optional bool
+	options  []model.PlannerCandidate
+}
+
+// PlanTimetable 在已有课表的基础上，枚举候选课程的无冲突组合并按偏好排序
+func PlanTimetable(cookie string, req model.PlannerRequest) (*model.PlannerResponse, error) {
+	if err := checkPlannerSize(req.Candidates); err != nil {
+		return nil, err
+	}
+
+	var fixed []model.ClassSchedules
+	if !req.IgnoreFixed {
+		schedules, err := FetchTermClassSchedules(cookie, req.Term)
+		if err != nil && !errors.Is(err, ErrResourceNotFound) {
+			return nil, err
+		}
+		fixed = schedules
+	}
+	return planTimetable(fixed, req), nil
+}
+
+// checkPlannerSize 检查候选课程数量和每门课的教学班数量是否超过上限
+func checkPlannerSize(candidates []model.PlannerCandidate) error {
+	courses := groupPlannerCandidates(candidates)
+	if len(courses) > plannerMaxCourses {
+		return ErrPlannerTooLarge
+	}
+	for _, course := range courses {
+		if len(course.options) > plannerMaxOptions {
+			return ErrPlannerTooLarge
+		}
+	}
+	return nil
+}
+
+// planTimetable 规划的核心逻辑：回溯枚举每门课的一个教学班 (可选课程允许不选)
+func planTimetable(fixed []model.ClassSchedules, req model.PlannerRequest) *model.PlannerResponse {
+	maxResults := req.MaxResults
+	if maxResults < 1 {
+		maxResults = plannerDefaultResult
+	}
+	maxResults = min(maxResults, plannerMaxResult)
+
+	fixedSlots := make([]plannerSlot, 0, len(fixed))
+	for _, f := range fixed {
+		fixedSlots = append(fixedSlots, plannerSlot{
+			day:      f.TimeParsed.DayOfWeek,
+			periods:  f.TimeParsed.PeriodArray,
+			weeks:    f.TimeParsed.Weeks,
+			location: f.Location,
+		})
+	}
+
+	courses := groupPlannerCandidates(req.Candidates)
+	response := &model.PlannerResponse{
+		FixedCourses: len(fixed),
+		Plans:        []model.PlannerPlan{},
+	}
+
+	var plans []model.PlannerPlan
+	chosen := make([]model.PlannerCandidate, 0, len(courses))
+	skipped := make([]string, 0)
+	occupied := slices.Clone(fixedSlots)
+
+	var search func(i int)
+	search = func(i int) {
+		if response.Truncated {
+			return
+		}
+		// 每个搜索节点都计数，包括因冲突没有后续分支的节点
+		response.Explored++
+		if response.Explored >= plannerMaxExplored {
+			response.Truncated = true
+		}
+		if i == len(courses) {
+			plans = append(plans, scorePlannerPlan(chosen, skipped, occupied, req.Preferences))
+			return
+		}
+
+		course := courses[i]
+		for _, option := range course.options {
+			slots := candidateSlots(option)
+			if slotsConflict(slots, occupied) {
+				continue
+			}
+			chosen = append(chosen, option)
+			occupied = append(occupied, slots...)
+			search(i + 1)
+			occupied = occupied[:len(occupied)-len(slots)]
+			chosen = chosen[:len(chosen)-1]
+		}
+		if course.optional {
+			skipped = append(skipped, course.name)
+			search(i + 1)
+			skipped = skipped[:len(skipped)-1]
+		}
+	}
+	search(0)
+
+	// 选上的课程越多越好，其次按偏好得分排序
+	sort.SliceStable(plans, func(a, b int) bool {
+		if len(plans[a].Selections) != len(plans[b].Selections) {
+			return len(plans[a].Selections) > len(plans[b].Selections)
+		}
+		return plans[a].Score > plans[b].Score
+	})
+	if len(plans) > maxResults {
+		plans = plans[:maxResults]
+	}
+	for i := range plans {
+		plans[i].Rank = i + 1
+	}
+	if plans != nil {
+		response.Plans = plans
+	}
+	return response
+}
+
+// groupPlannerCandidates 按课程名称合并候选教学班，保持首次出现的顺序
+func groupPlannerCandidates(candidates []model.PlannerCandidate) []*plannerCourse {
+	var courses []*plannerCourse
+	index := make(map[string]*plannerCourse)
+	for _, c := range candidates {
+		name := strings.TrimSpace(c.CourseName)
+		if name == "" {
+			continue
+		}
+		course, ok := index[name]
+		if !ok {
+			course = &plannerCourse{name: name}
+			index[name] = course
+			courses = append(courses, course)
+		}
+		course.optional = course.optional || c.Optional
+		course.options = append(course.options, c)
+	}
+	return courses
+}
+
+// candidateSlots 把候选教学班的上课时间转换为时间段
+func candidateSlots(c model.PlannerCandidate) []plannerSlot {
+	location := c.Location
+	if c.Campus != "" {
+		location = c.Campus + " " + c.Location
+	}
+	slots := make([]plannerSlot, 0, len(c.Times))
+	for _, t := range c.Times {
+		slots = append(slots, plannerSlot{day: t.DayOfWeek, periods: t.PeriodArray, weeks: t.Weeks, location: location})
+	}
+	return slots
+}
+
+// slotsConflict 判断一组时间段是否与已占用的时间段冲突
+func slotsConflict(slots, occupied []plannerSlot) bool {
+	for _, s := range slots {
+		for _, o := range occupied {
+			if s.day != o.day || len(intersectInts(s.periods, o.periods)) == 0 {
+				continue
+			}
+			// 周次为空表示全部周次，必然重叠
+			if len(s.weeks) == 0 || len(o.weeks) == 0 || len(intersectInts(s.weeks, o.weeks)) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scorePlannerPlan 计算一个组合在各项偏好上的表现
+func scorePlannerPlan(chosen []model.PlannerCandidate, skipped []string, occupied []plannerSlot, prefs model.PlannerPreferences) model.PlannerPlan {
+	plan := model.PlannerPlan{
+		Selections: slices.Clone(chosen),
+		Skipped:    append([]string{}, skipped...),
+	}
+
+	for _, s := range occupied {
+		if prefs.NoEarlyClasses && slices.ContainsFunc(s.periods, func(p int) bool { return p <= earlyClassLastPeriod }) {
+			plan.EarlyClasses++
+		}
+		if slices.Contains(prefs.FreeDays, s.day) {
+			plan.FreeDayClasses++
+		}
+	}
+	if prefs.MinimiseCampusHops {
+		plan.CampusHops = countLocationHops(occupied)
+	}
+
+	plan.Score = -(float64(plan.EarlyClasses)*penaltyEarlyClass +
+		float64(plan.FreeDayClasses)*penaltyFreeDayClass +
+		float64(plan.CampusHops)*penaltyCampusHop)
+	return plan
+}
+
+// countLocationHops 统计每天按节次排序后，相邻两次课地点所在校区 / 楼不同的次数
+// 不区分周次，按整学期的周视图近似计算
+func countLocationHops(slots []plannerSlot) int {
+	byDay := make(map[int][]plannerSlot)
+	for _, s := range slots {
+		if len(s.periods) == 0 || s.location == "" {
+			continue
+		}
+		byDay[s.day] = append(byDay[s.day], s)
+	}
+
+	hops := 0
+	for _, daySlots := range byDay {
+		sort.Slice(daySlots, func(a, b int) bool {
+			return slices.Min(daySlots[a].periods) < slices.Min(daySlots[b].periods)
+		})
+		for i := 1; i < len(daySlots); i++ {
+			if buildingOf(daySlots[i-1].location) != buildingOf(daySlots[i].location) {
+				hops++
+			}
+		}
+	}
+	return hops
+}
+
+// buildingOf 从上课地点中提取校区 / 楼名，如 "格物楼B101" -> "格物楼"
+func buildingOf(location string) string {
+	location = strings.TrimSpace(location)
+	if idx := strings.Index(location, "楼"); idx >= 0 {
+		return location[:idx+len("楼")]
+	}
+	return strings.TrimRightFunc(location, func(r rune) bool {
+		return (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || r == '-'
+	})
+}
@@ -0,0 +1,206 @@
+package zhjw
+
+import (
+	"bytes"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// profileCacheTTL 学籍信息基本不会变化，缓存一段时间供其他接口自动选择校区 / 年级
+const profileCacheTTL = 30 * time.Minute
+
+// profileBasics 缓存中只保存自动选择校区 / 年级 / 专业用到的非敏感字段
+// 身份证号、电话等敏感信息不进缓存，每次都向教务系统获取，确保登录态仍然有效
+type profileBasics struct {
+	Campus string
+	Grade  string
+	Major  string
+}
+
+type cachedProfile struct {
+	basics    profileBasics
+	expiresAt time.Time
+}
+
+var profileCache = struct {
+	sync.RWMutex
+	entries map[string]cachedProfile
+}{entries: make(map[string]cachedProfile)}
+
+// gradePattern 从入学日期或学号开头提取年级
+var gradePattern = regexp.MustCompile(`^(20\d{2})`)
+
+// FetchStudentProfile 获取学籍信息，reveal 为 false 时对身份证号和电话脱敏
+// 始终向教务系统获取，不使用缓存
+func FetchStudentProfile(cookie string, reveal bool) (*model.StudentProfile, error) {
+	profile, err := fetchStudentProfile(cookie)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reveal {
+		profile.IDNumber = maskMiddle(profile.IDNumber, 4, 4)
+		profile.Phone = maskMiddle(profile.Phone, 3, 4)
+		profile.Masked = true
+	}
+	return profile, nil
+}
+
+// loadProfileBasics 获取校区、年级和专业，优先使用缓存，仅供服务内部自动选择使用
+func loadProfileBasics(cookie string) (*profileBasics, error) {
+	profileCache.RLock()
+	entry, ok := profileCache.entries[cookie]
+	profileCache.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		basics := entry.basics
+		return &basics, nil
+	}
+
+	profile, err := fetchStudentProfile(cookie)
+	if err != nil {
+		return nil, err
+	}
+	return &profileBasics{Campus: profile.Campus, Grade: profile.Grade, Major: profile.Major}, nil
+}
+
+// fetchStudentProfile 向教务系统获取未脱敏的学籍信息，并刷新非敏感字段的缓存
+func fetchStudentProfile(cookie string) (*model.StudentProfile, error) {
+	client := NewClient(cookie)
+
+	slog.Info("开始获取学籍信息",
+		"cookie_len", len(cookie), // 不要记录完整 cookie，记录长度即可，保护隐私
+	)
+	resp, err := client.R().
+		Get("http://zhjw.qfnu.edu.cn/jsxsd/grxx/xsxx")
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := parseStudentProfileHtml(resp.Body())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	profileCache.Lock()
+	// 顺带清理过期条目，避免缓存无限增长
+	for key, e := range profileCache.entries {
+		if now.After(e.expiresAt) {
+			delete(profileCache.entries, key)
+		}
+	}
+	profileCache.entries[cookie] = cachedProfile{
+		basics:    profileBasics{Campus: profile.Campus, Grade: profile.Grade, Major: profile.Major},
+		expiresAt: now.Add(profileCacheTTL),
+	}
+	profileCache.Unlock()
+
+	return profile, nil
+}
+
+// parseStudentProfileHtml 解析学籍卡片 HTML
+// 学籍卡片 #xjkpTable 中的字段有两种写法："院系：计算机学院" 写在同一个单元格，
+// 或者 "院系：" 和 "计算机学院" 分别在相邻的两个单元格
+func parseStudentProfileHtml(htmlBody []byte) (*model.StudentProfile, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	doc.Find("#xjkpTable tr").Each(func(_ int, tr *goquery.Selection) {
+		cells := tr.Find("td")
+		for i := 0; i < cells.Length(); i++ {
+			text := strings.TrimSpace(cells.Eq(i).Text())
+			label, value, found := strings.Cut(strings.ReplaceAll(text, ":", "："), "：")
+			label = strings.Join(strings.Fields(label), "")
+			// 部分字段的标签不带冒号，只认已知的标签名
+			if !found && !profileLabels[label] {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			if value == "" && i+1 < cells.Length() {
+				value = strings.TrimSpace(cells.Eq(i + 1).Text())
+				i++
+			}
+			if label != "" {
+				if _, exists := fields[label]; !exists {
+					fields[label] = value
+				}
+			}
+		}
+	})
+
+	get := func(labels ...string) string {
+		for _, l := range labels {
+			if v := fields[l]; v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	profile := &model.StudentProfile{
+		StudentID: get("学号"),
+		Name:      get("姓名"),
+		Gender:    get("性别"),
+		College:   get("院系", "学院"),
+		Major:     get("专业", "专业名称"),
+		Grade:     get("年级"),
+		Class:     get("班级"),
+		Campus:    get("校区"),
+		EntryDate: get("入学日期", "入学时间"),
+		IDNumber:  get("身份证编号", "身份证号"),
+		Phone:     get("本人电话", "联系电话", "手机号码"),
+	}
+
+	// 年级缺失时从入学日期或学号前四位推断
+	if profile.Grade == "" {
+		if m := gradePattern.FindStringSubmatch(profile.EntryDate); m != nil {
+			profile.Grade = m[1]
+		} else if m := gradePattern.FindStringSubmatch(profile.StudentID); m != nil {
+			profile.Grade = m[1]
+		}
+	}
+
+	if profile.StudentID == "" && profile.Name == "" {
+		return nil, ErrResourceNotFound
+	}
+	return profile, nil
+}
+
+// profileLabels 学籍卡片中会用到的字段标签
+var profileLabels = map[string]bool{
+	"学号": true, "姓名": true, "性别": true, "院系": true, "学院": true,
+	"专业": true, "专业名称": true, "年级": true, "班级": true, "校区": true,
+	"入学日期": true, "入学时间": true, "身份证编号": true, "身份证号": true,
+	"本人电话": true, "联系电话": true, "手机号码": true,
+}
+
+// maskMiddle 保留首尾若干字符，中间用星号替换；长度不足时全部替换
+func maskMiddle(s string, head, tail int) string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return s
+	}
+	if len(runes) <= head+tail {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:head]) + strings.Repeat("*", len(runes)-head-tail) + string(runes[len(runes)-tail:])
+}
+
+// profileCampus 获取学生所在校区，失败时返回空字符串 (调用方按不限校区处理)
+func profileCampus(cookie string) string {
+	basics, err := loadProfileBasics(cookie)
+	if err != nil {
+		slog.Warn("获取学籍信息失败，无法自动选择校区", "error", err)
+		return ""
+	}
+	return basics.Campus
+}
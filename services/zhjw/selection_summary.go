@@ -0,0 +1,129 @@
+package zhjw
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/W1ndys/easy-qfnu-api-go/internal/config"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// FetchSelectionSummary 获取选课结果并附带学分学时统计和课表时段
+func FetchSelectionSummary(cookie string, term string) (*model.SelectionResultsResponse, error) {
+	results, err := FetchSelectionResults(cookie, term)
+	if err != nil {
+		return nil, err
+	}
+
+	// 课表只用于补充上课时段，获取失败不影响选课结果本身
+	schedules, err := FetchTermClassSchedules(cookie, term)
+	if errors.Is(err, ErrCookieExpired) {
+		return nil, err
+	} else if err != nil {
+		slog.Warn("获取学期课表失败，跳过上课时段匹配", "term", term, "error", err)
+		schedules = nil
+	}
+
+	minCredits, maxCredits := config.GetSelectionCreditLimits()
+	return summarizeSelectionResults(results, schedules, minCredits, maxCredits), nil
+}
+
+// summarizeSelectionResults 统计选课结果，并按课程名称匹配课表中的上课时段
+func summarizeSelectionResults(results []model.SelectionResult, schedules []model.ClassSchedules, minCredits, maxCredits float64) *model.SelectionResultsResponse {
+	slots := make(map[string][]model.SelectionSlot)
+	for _, s := range schedules {
+		name := strings.TrimSpace(s.Name)
+		slots[name] = append(slots[name], model.SelectionSlot{
+			DayOfWeek:     s.TimeParsed.DayOfWeek,
+			Periods:       s.TimeParsed.PeriodArray,
+			Weeks:         s.TimeParsed.Weeks,
+			Location:      s.Location,
+			RawTimeString: s.RawTimeString,
+		})
+	}
+
+	summary := model.SelectionSummary{
+		CourseCount:     len(results),
+		ScheduleMatched: len(schedules) > 0,
+	}
+	byProp := newSelectionTotals()
+	byAttr := newSelectionTotals()
+
+	for i := range results {
+		r := &results[i]
+		credits, _ := strconv.ParseFloat(strings.TrimSpace(r.Credit), 64)
+		hours, _ := strconv.ParseFloat(strings.TrimSpace(r.Hours), 64)
+
+		summary.TotalCredits += credits
+		summary.TotalHours += hours
+		byProp.add(r.CourseProp, credits, hours)
+		byAttr.add(r.CourseAttr, credits, hours)
+
+		r.Slots = slots[strings.TrimSpace(r.CourseName)]
+	}
+
+	summary.TotalCredits = round2(summary.TotalCredits)
+	summary.TotalHours = round2(summary.TotalHours)
+	summary.ByCourseProp = byProp.list()
+	summary.ByCourseAttr = byAttr.list()
+	summary.CreditLimit = checkCreditLimit(summary.TotalCredits, minCredits, maxCredits)
+
+	return &model.SelectionResultsResponse{
+		Results: results,
+		Summary: summary,
+	}
+}
+
+// checkCreditLimit 比较总学分与学期学分限制，限制为 0 时视为不限制
+func checkCreditLimit(total, minCredits, maxCredits float64) model.SelectionCreditLimit {
+	limit := model.SelectionCreditLimit{
+		MinCredits: minCredits,
+		MaxCredits: maxCredits,
+		Status:     model.CreditLimitOK,
+	}
+	if minCredits > 0 && total < minCredits {
+		limit.Status = model.CreditLimitBelow
+	} else if maxCredits > 0 && total > maxCredits {
+		limit.Status = model.CreditLimitAbove
+	}
+	return limit
+}
+
+// selectionTotals 按名称分组累计学分学时，保持首次出现的顺序
+type selectionTotals struct {
+	order  []string
+	totals map[string]*model.SelectionGroupTotal
+}
+
+func newSelectionTotals() *selectionTotals {
+	return &selectionTotals{totals: make(map[string]*model.SelectionGroupTotal)}
+}
+
+func (t *selectionTotals) add(name string, credits, hours float64) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "未知"
+	}
+	total, ok := t.totals[name]
+	if !ok {
+		total = &model.SelectionGroupTotal{Name: name}
+		t.totals[name] = total
+		t.order = append(t.order, name)
+	}
+	total.Count++
+	total.Credits += credits
+	total.Hours += hours
+}
+
+func (t *selectionTotals) list() []model.SelectionGroupTotal {
+	list := make([]model.SelectionGroupTotal, 0, len(t.order))
+	for _, name := range t.order {
+		total := *t.totals[name]
+		total.Credits = round2(total.Credits)
+		total.Hours = round2(total.Hours)
+		list = append(list, total)
+	}
+	return list
+}
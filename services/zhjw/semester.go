@@ -0,0 +1,84 @@
+package zhjw
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// entryYearOf 根据成绩中最早的学期推断入学年份，如 "2023-2024-1" -> 2023，无法推断时返回 0
+func entryYearOf(grades []model.Grade) int {
+	entry := 0
+	for _, g := range grades {
+		year, _, ok := splitTerm(g.Semester)
+		if ok && (entry == 0 || year < entry) {
+			entry = year
+		}
+	}
+	return entry
+}
+
+// semesterIndexOf 计算某学年学期是入学后的第几学期，如入学 2023 年，"2024-2025-1" 为第 3 学期
+func semesterIndexOf(entryYear int, term string) int {
+	year, no, ok := splitTerm(term)
+	if !ok || entryYear == 0 {
+		return 0
+	}
+	index := (year-entryYear)*2 + no
+	if index < 1 {
+		return 0
+	}
+	return index
+}
+
+// splitTerm 拆分学年学期，如 "2024-2025-1" -> (2024, 1)
+func splitTerm(term string) (startYear int, no int, ok bool) {
+	parts := strings.Split(strings.TrimSpace(term), "-")
+	if len(parts) != 3 {
+		return 0, 0, false
+	}
+	startYear, err1 := strconv.Atoi(parts[0])
+	no, err2 := strconv.Atoi(parts[2])
+	if err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+	return startYear, no, true
+}
+
+// currentTermOr 返回指定的学年学期，为空时根据当前日期推断
+func currentTermOr(term string) string {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return termOfDate(time.Now())
+	}
+	return term
+}
+
+// planTermIndex 解析培养方案中的开设学期，如 "3" -> 3，无法解析时返回 0
+func planTermIndex(term string) int {
+	m := regexp.MustCompile(`\d+`).FindString(term)
+	index, _ := strconv.Atoi(m)
+	return index
+}
+
+// isPassed 判断成绩是否及格，支持百分制和等级制
+func isPassed(score string) bool {
+	score = strings.TrimSpace(score)
+	if v, err := strconv.ParseFloat(score, 64); err == nil {
+		return v >= 60
+	}
+	for _, fail := range []string{"不及格", "不合格", "缺考", "作弊", "违纪"} {
+		if strings.Contains(score, fail) {
+			return false
+		}
+	}
+	for _, pass := range []string{"及格", "中等", "良好", "优秀", "合格", "通过", "免修"} {
+		if strings.Contains(score, pass) {
+			return true
+		}
+	}
+	return false
+}
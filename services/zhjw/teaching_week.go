@@ -0,0 +1,165 @@
+package zhjw
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	calendarService "github.com/W1ndys/easy-qfnu-api-go/services/calendar"
+)
+
+// semesterInfo 一个学期的教学周历信息
+type semesterInfo struct {
+	start      time.Time // 第 1 周的周一
+	totalWeeks int       // 本学期总周数
+}
+
+// semesterCache 缓存由课程表推算出的学期开始日期，按学年学期 (如 2025-2026-1) 索引
+// 教学周历对所有学生一致，所以全局缓存一份即可
+var semesterCache = struct {
+	sync.RWMutex
+	terms map[string]semesterInfo
+}{terms: make(map[string]semesterInfo)}
+
+// currentWeekPattern 课程表页面上的当前周次，如 "第18周/20周"
+var currentWeekPattern = regexp.MustCompile(`第(\d+)周\s*/\s*(\d+)周`)
+
+// parseCurrentWeek 解析 "第18周/20周" 或 "当前日期不在教学周历内"
+func parseCurrentWeek(raw string) (current int, total int, inTerm bool) {
+	matches := currentWeekPattern.FindStringSubmatch(raw)
+	if len(matches) != 3 {
+		return 0, 0, false
+	}
+	current, _ = strconv.Atoi(matches[1])
+	total, _ = strconv.Atoi(matches[2])
+	return current, total, current > 0
+}
+
+// parseScheduleDate 解析课程表查询日期，为空时使用今天
+func parseScheduleDate(date string) (time.Time, error) {
+	date = strings.TrimSpace(date)
+	if date == "" {
+		return calendarService.TruncateToDay(time.Now()), nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t, nil
+}
+
+// inferSemesterStart 根据某一天及其所在教学周推算第 1 周的周一
+func inferSemesterStart(date time.Time, week int) time.Time {
+	return calendarService.MondayOf(date).AddDate(0, 0, -(week-1)*7)
+}
+
+// rememberSemesterStart 更新学期开始日期缓存
+func rememberSemesterStart(start time.Time, totalWeeks int) {
+	semesterCache.Lock()
+	defer semesterCache.Unlock()
+	semesterCache.terms[termOfDate(start)] = semesterInfo{start: start, totalWeeks: totalWeeks}
+}
+
+// SemesterStartOf 返回某学期开始日期和总周数，优先使用管理员维护的校历，其次是缓存
+// ok 为 false 表示校历中没有该学期且尚未推算过
+func SemesterStartOf(term string) (start time.Time, totalWeeks int, ok bool) {
+	if start, totalWeeks, ok := calendarService.TermStart(term); ok {
+		return start, totalWeeks, true
+	}
+
+	semesterCache.RLock()
+	defer semesterCache.RUnlock()
+	info, ok := semesterCache.terms[strings.TrimSpace(term)]
+	if !ok {
+		return time.Time{}, 0, false
+	}
+	return info.start, info.totalWeeks, true
+}
+
+// TeachingWeekOf 根据缓存的学期开始日期计算任意日期的教学周
+// 返回的 inTerm 表示该日期是否落在教学周历内，ok 为 false 表示该日期所属学期的缓存为空
+func TeachingWeekOf(date time.Time) (week int, inTerm bool, ok bool) {
+	start, total, ok := SemesterStartOf(termOfDate(date))
+	if !ok {
+		return 0, false, false
+	}
+
+	week, _ = weekAndDayOf(start, date)
+	return week, week > 0 && week <= total, true
+}
+
+// weekAndDayOf 根据学期开始日期计算日期所在的教学周和星期，学期开始前周次为 0
+func weekAndDayOf(start time.Time, date time.Time) (week int, dayOfWeek int) {
+	date = calendarService.TruncateToDay(date)
+	dayOfWeek = (int(date.Weekday())+6)%7 + 1
+
+	days := int(math.Round(calendarService.MondayOf(date).Sub(start).Hours() / 24))
+	if days < 0 {
+		return 0, dayOfWeek
+	}
+	return days/7 + 1, dayOfWeek
+}
+
+// dateOf 根据学期开始日期计算某教学周某天的日期
+func dateOf(start time.Time, week int, dayOfWeek int) time.Time {
+	return start.AddDate(0, 0, (week-1)*7+dayOfWeek-1)
+}
+
+// ResolveTeachingWeek 获取某一天所在的教学周和星期
+// 优先使用管理员维护的校历 (调休补课日期返回实际执行课表的周次和星期)，
+// 其次使用缓存的学期开始日期，都缺失或不在本学期时请求一次课程表刷新缓存
+func ResolveTeachingWeek(cookie string, date time.Time) (week int, dayOfWeek int, err error) {
+	date = calendarService.TruncateToDay(date)
+	dayOfWeek = (int(date.Weekday())+6)%7 + 1
+
+	if day, err := calendarService.Resolve(date); err == nil && day.ScheduleWeek > 0 {
+		return day.ScheduleWeek, day.ScheduleDayOfWeek, nil
+	}
+
+	if w, inTerm, ok := TeachingWeekOf(date); ok && inTerm {
+		return w, dayOfWeek, nil
+	}
+
+	schedule, err := FetchClassSchedules(cookie, date.Format("2006-01-02"))
+	if err != nil {
+		return 0, 0, err
+	}
+	if !schedule.InTerm {
+		return 0, 0, ErrDateNotInTerm
+	}
+	return schedule.CurrentWeek, dayOfWeek, nil
+}
+
+// termOfDate 根据日期推断学年学期，如 2025-09-01 -> 2025-2026-1
+// 8 月到次年 1 月为第一学期，2 月到 7 月为第二学期
+func termOfDate(date time.Time) string {
+	year := date.Year()
+	switch month := date.Month(); {
+	case month >= time.August:
+		return fmt.Sprintf("%d-%d-1", year, year+1)
+	case month == time.January:
+		return fmt.Sprintf("%d-%d-1", year-1, year)
+	default:
+		return fmt.Sprintf("%d-%d-2", year-1, year)
+	}
+}
+
+// resolveSemesterStart 获取某学期的开始日期
+// 缓存缺失时使用学期内的某一天 (probe) 请求一次课程表来推算，仍无法确定时 ok 为 false
+func resolveSemesterStart(cookie string, term string, probe time.Time) (start time.Time, ok bool) {
+	if start, _, ok := SemesterStartOf(term); ok {
+		return start, true
+	}
+	if probe.IsZero() {
+		return time.Time{}, false
+	}
+	if _, _, err := ResolveTeachingWeek(cookie, probe); err != nil {
+		return time.Time{}, false
+	}
+	start, _, ok = SemesterStartOf(term)
+	return start, ok
+}
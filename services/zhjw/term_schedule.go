@@ -0,0 +1,196 @@
+package zhjw
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// FetchTermClassSchedules 抓取整个学期的个人课表 (含每门课的上课周次)
+func FetchTermClassSchedules(cookie string, term string) ([]model.ClassSchedules, error) {
+
+	// 使用工厂函数创建 Client (自带检查功能)
+	client := NewClient(cookie)
+
+	targetURL := "http://zhjw.qfnu.edu.cn/jsxsd/xskb/xskb_list.do"
+	formData := map[string]string{
+		"xnxq01id": strings.TrimSpace(term), // 学年学期
+	}
+
+	// 记录重要的业务行为
+	slog.Info("开始抓取学期课表",
+		"term", term,
+		"cookie_len", len(cookie), // 不要记录完整 cookie，记录长度即可，保护隐私
+	)
+	// 发起 POST 请求
+	resp, err := client.R().
+		SetFormData(formData).
+		Post(targetURL)
+
+	// 错误处理
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTermClassSchedulesHtml(resp.Body())
+}
+
+// parseTermClassSchedulesHtml 解析学期课表 HTML
+// 表格 #kbtable 每行是一个大节，每列是一天；单元格中 div.kbcontent 为完整信息，多门课程之间以 "-----" 分隔
+// 格式: 高等数学<br/><font title='老师'>张三</font><br/><font title='周次(节次)'>1-16(周)[01-02节]</font><br/><font title='教室'>格物楼B101</font>
+func parseTermClassSchedulesHtml(htmlBody []byte) ([]model.ClassSchedules, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
+	if err != nil {
+		return nil, err
+	}
+
+	courses := make([]model.ClassSchedules, 0)
+	seen := make(map[string]bool) // 跨多个大节的课程会在每个大节重复出现，需要去重
+	reSplit := regexp.MustCompile(`-{5,}`)
+	index := 1
+
+	doc.Find("#kbtable tr").Each(func(i int, s *goquery.Selection) {
+		s.Find("td").Each(func(j int, td *goquery.Selection) {
+			dayOfWeek := j + 1
+			if dayOfWeek > 7 {
+				return
+			}
+
+			content := td.Find("div.kbcontent")
+			if content.Length() == 0 {
+				return
+			}
+			contentHtml, _ := content.First().Html()
+
+			for _, part := range reSplit.Split(contentHtml, -1) {
+				course, ok := parseTermCourseBlock(part, dayOfWeek)
+				if !ok {
+					continue
+				}
+				key := course.Name + "|" + course.RawTimeString + "|" + course.Location
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				course.Index = index
+				courses = append(courses, course)
+				index++
+			}
+		})
+	})
+
+	return courses, nil
+}
+
+// parseTermCourseBlock 解析单门课程的 HTML 片段
+func parseTermCourseBlock(part string, dayOfWeek int) (model.ClassSchedules, bool) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader("<div>" + part + "</div>"))
+	if err != nil {
+		return model.ClassSchedules{}, false
+	}
+	block := doc.Find("div").First()
+
+	course := model.ClassSchedules{}
+	weekText := ""
+	block.Find("font").Each(func(_ int, f *goquery.Selection) {
+		title, _ := f.Attr("title")
+		text := strings.TrimSpace(f.Text())
+		switch title {
+		case "老师":
+			course.Teacher = text
+		case "周次(节次)":
+			weekText = text
+		case "教室":
+			course.Location = text
+		case "分组":
+			course.Classes = text
+		}
+	})
+
+	// 课程名称是第一个 font 之前的文本
+	block.Find("font").Remove()
+	blockHtml, _ := block.Html()
+	reBr := regexp.MustCompile(`(?i)<br\s*/?>`)
+	reTag := regexp.MustCompile(`<[^>]+>`)
+	for _, line := range reBr.Split(blockHtml, -1) {
+		line = strings.TrimSpace(html.UnescapeString(reTag.ReplaceAllString(line, "")))
+		if line != "" {
+			course.Name = line
+			break
+		}
+	}
+	if course.Name == "" || weekText == "" {
+		return model.ClassSchedules{}, false
+	}
+
+	// 1-16(周)[01-02节] -> 周次部分 + 节次部分
+	weekPart, periodPart := weekText, ""
+	if idx := strings.Index(weekText, "["); idx >= 0 {
+		weekPart, periodPart = weekText[:idx], weekText[idx:]
+	}
+	periods := parsePeriodList(periodPart)
+
+	course.RawTimeString = fmt.Sprintf("%s 星期%s %s", weekPart, weekdayNames[dayOfWeek-1], periodPart)
+	course.TimeParsed = model.ClassTimeParse{
+		DayOfWeek:   dayOfWeek,
+		PeriodArray: periods,
+		Weeks:       parseWeekList(weekPart),
+	}
+	return course, true
+}
+
+// parsePeriodList 解析节次，如 "[01-02-03节]" -> [1 2 3]
+func parsePeriodList(raw string) []int {
+	var periods []int
+	for _, m := range regexp.MustCompile(`\d+`).FindAllString(raw, -1) {
+		if p, err := strconv.Atoi(m); err == nil {
+			periods = append(periods, p)
+		}
+	}
+	return periods
+}
+
+// parseWeekList 解析周次，支持 "1-16(周)"、"1-8,10-16(周)"、"2-16(双周)"、"3,5,7(周)" 等写法
+func parseWeekList(raw string) []int {
+	odd := strings.Contains(raw, "单")
+	even := strings.Contains(raw, "双")
+
+	// 只保留数字和分隔符，去掉 "(周)"、"双周" 等说明文字
+	raw = regexp.MustCompile(`[^\d,，\-]`).ReplaceAllString(raw, "")
+
+	set := make(map[int]bool)
+	for _, seg := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == '，' }) {
+		bounds := strings.SplitN(strings.TrimSpace(seg), "-", 2)
+		from, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			continue
+		}
+		to := from
+		if len(bounds) == 2 {
+			if v, err := strconv.Atoi(strings.TrimSpace(bounds[1])); err == nil {
+				to = v
+			}
+		}
+		for w := from; w <= to; w++ {
+			if (odd && w%2 == 0) || (even && w%2 == 1) {
+				continue
+			}
+			set[w] = true
+		}
+	}
+
+	weeks := make([]int, 0, len(set))
+	for w := range set {
+		weeks = append(weeks, w)
+	}
+	sort.Ints(weeks)
+	return weeks
+}
@@ -0,0 +1,219 @@
+package zhjw
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/W1ndys/easy-qfnu-api-go/model"
+)
+
+// TimetableKind 公共课表查询类型
+type TimetableKind string
+
+const (
+	TimetableTeacher   TimetableKind = "teacher"   // 教师课表
+	TimetableClass     TimetableKind = "class"     // 班级课表
+	TimetableClassroom TimetableKind = "classroom" // 教室课表
+)
+
+// timetableEndpoints 各类型对应的上游地址和名称字段
+var timetableEndpoints = map[TimetableKind]struct {
+	url       string
+	nameField string
+}{
+	TimetableTeacher:   {"http://zhjw.qfnu.edu.cn/jsxsd/kbcx/kbxx_teacher_ifr", "skjs"},
+	TimetableClass:     {"http://zhjw.qfnu.edu.cn/jsxsd/kbcx/kbxx_xzb_ifr", "skbj"},
+	TimetableClassroom: {"http://zhjw.qfnu.edu.cn/jsxsd/kbcx/kbxx_classroom_ifr", "jsmc"},
+}
+
+// FetchTimetable 通过教务系统的公共课表查询获取教师 / 班级 / 教室课表
+func FetchTimetable(cookie string, kind TimetableKind, req model.TimetableSearchRequest) (*model.TimetableSearchResponse, error) {
+	endpoint, ok := timetableEndpoints[kind]
+	if !ok {
+		return nil, fmt.Errorf("不支持的课表类型: %s", kind)
+	}
+
+	// 使用工厂函数创建 Client (自带检查功能)
+	client := NewClient(cookie)
+
+	formData := map[string]string{
+		"xnxqh":            strings.TrimSpace(req.Term), // 学年学期
+		endpoint.nameField: strings.TrimSpace(req.Name), // 教师 / 班级 / 教室名称
+	}
+	if req.Week > 0 {
+		formData["zc1"] = strconv.Itoa(req.Week) // 开始周次
+		formData["zc2"] = strconv.Itoa(req.Week) // 结束周次
+	}
+
+	// 记录重要的业务行为
+	slog.Info("开始查询公共课表",
+		"kind", kind,
+		"name", req.Name,
+		"term", req.Term,
+		"week", req.Week,
+		"cookie_len", len(cookie), // 不要记录完整 cookie，记录长度即可，保护隐私
+	)
+	// 发起 POST 请求
+	resp, err := client.R().
+		SetFormData(formData).
+		Post(endpoint.url)
+
+	// 错误处理
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := parseTimetableHtml(resp.Body(), req.Week)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TimetableSearchResponse{
+		Term:    strings.TrimSpace(req.Term),
+		Week:    req.Week,
+		Results: results,
+	}, nil
+}
+
+// parseTimetableHtml 解析公共课表 HTML
+// 表格 #kbtable 每行是一个教师 / 班级 / 教室：第一列为名称，后面按 星期一~星期日 × 每天的大节 依次排列
+// 单元格内每门课程是一个 div，内容依次为：课程名称、授课教师、上课周次 (含节次)、上课地点、上课班级 (以 <br> 分隔)
+func parseTimetableHtml(htmlBody []byte, week int) ([]model.TimetableOwner, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlBody))
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make([]model.TimetableOwner, 0)
+
+	doc.Find("#kbtable tr").Each(func(i int, s *goquery.Selection) {
+		tds := s.Find("td")
+		// 表头行使用 th，数据行至少要有 名称 + 7 天的单元格
+		if tds.Length() < 8 {
+			return
+		}
+
+		owner := model.TimetableOwner{
+			Name:    strings.TrimSpace(tds.First().Text()),
+			Courses: []model.ClassSchedules{},
+		}
+
+		cells := tds.Slice(1, tds.Length())
+		sectionsPerDay := cells.Length() / 7
+		index := 1
+
+		cells.Each(func(j int, cell *goquery.Selection) {
+			dayOfWeek := j/sectionsPerDay + 1
+			section := j%sectionsPerDay + 1
+			if dayOfWeek > 7 {
+				return
+			}
+
+			blocks := cell.Find("div")
+			if blocks.Length() == 0 {
+				blocks = cell
+			}
+			blocks.Each(func(_ int, block *goquery.Selection) {
+				course, ok := parseTimetableBlock(block, dayOfWeek, section, week)
+				if !ok {
+					return
+				}
+				course.Index = index
+				owner.Courses = append(owner.Courses, course)
+				index++
+			})
+		})
+
+		owners = append(owners, owner)
+	})
+
+	return owners, nil
+}
+
+// parseTimetableBlock 解析单元格内的一门课程
+func parseTimetableBlock(block *goquery.Selection, dayOfWeek, section, week int) (model.ClassSchedules, bool) {
+	blockHtml, _ := block.Html()
+
+	var lines []string
+	for _, part := range reTimetableBr.Split(blockHtml, -1) {
+		// 清除 html 标签并处理实体字符
+		text := strings.TrimSpace(html.UnescapeString(reTimetableTag.ReplaceAllString(part, "")))
+		if text != "" {
+			lines = append(lines, text)
+		}
+	}
+	if len(lines) == 0 {
+		return model.ClassSchedules{}, false
+	}
+
+	course := model.ClassSchedules{Name: lines[0]}
+	weeks := ""
+	var periods []int
+	var rest []string
+	for _, line := range lines[1:] {
+		// 节次一般跟在周次后面，如 "1-16(周)[01-02-03节]"，也可能单独占一行
+		if periods == nil {
+			if m := timetablePeriodPattern.FindStringSubmatchIndex(line); m != nil {
+				periods = expandPeriodRange(line[m[2]:m[3]])
+				line = strings.TrimSpace(line[:m[0]] + line[m[1]:])
+				if line == "" {
+					continue
+				}
+			}
+		}
+		if weeks == "" && weekRangePattern.MatchString(line) {
+			weeks = line
+			continue
+		}
+		rest = append(rest, line)
+	}
+	if len(rest) > 0 {
+		course.Teacher = rest[0]
+	}
+	if len(rest) > 1 {
+		course.Location = rest[1]
+	}
+	if len(rest) > 2 {
+		course.Classes = strings.Join(rest[2:], ",")
+	}
+
+	// 页面未标注节次时才按大节推算，每个大节按两个小节计
+	if len(periods) == 0 {
+		periods = []int{section*2 - 1, section * 2}
+	}
+	labels := make([]string, len(periods))
+	for i, p := range periods {
+		labels[i] = fmt.Sprintf("%02d", p)
+	}
+	course.RawTimeString = fmt.Sprintf("%s 星期%s [%s]节",
+		weeks, weekdayNames[dayOfWeek-1], strings.Join(labels, "-"))
+	course.TimeParsed = model.ClassTimeParse{
+		Week:        week,
+		DayOfWeek:   dayOfWeek,
+		PeriodArray: periods,
+		Weeks:       parseWeekList(weeks),
+	}
+	return course, true
+}
+
+// 单元格内容以 <br> 分隔，取文本前先去掉其余标签
+var (
+	reTimetableBr  = regexp.MustCompile(`(?i)<br\s*/?>`)
+	reTimetableTag = regexp.MustCompile(`<[^>]+>`)
+)
+
+// timetablePeriodPattern 单元格中的节次，如 "[01-02]节"、"[09-10-11节]"、"01-04节"
+var timetablePeriodPattern = regexp.MustCompile(`\[?\s*(\d{1,2}(?:\s*-\s*\d{1,2})*)\s*\]?\s*节\]?`)
+
+// weekRangePattern 上课周次行，如 "1-16(周)"、"2-16(双周)"、"1,3,5周"
+// 只按 "周" 字判断会把姓周的教师误认为周次
+var weekRangePattern = regexp.MustCompile(`^[\d,，\-\s]+(\([单双]?周\)|[单双]?周)`)
+
+// weekdayNames 星期的中文写法，与个人课表的 "星期一" 保持一致
+var weekdayNames = []string{"一", "二", "三", "四", "五", "六", "日"}
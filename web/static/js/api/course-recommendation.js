@@ -1,9 +1,9 @@
 // 选课推荐 API 封装
 window.CourseRecommendationApi = {
-    // 查询课程推荐
-    async query(keyword) {
+    // 查询课程推荐，filters 可包含 campus、recommendation_year、min_rating、page、page_size
+    async query(keyword, filters = {}) {
         return await window.request.get('/api/v1/course-recommendation/query', {
-            params: { keyword }
+            params: { keyword, ...filters }
         });
     },
 
@@ -12,6 +12,28 @@ window.CourseRecommendationApi = {
         return await window.request.post('/api/v1/course-recommendation/recommend', data);
     },
 
+    // 根据提交时返回的凭证查询投稿审核状态
+    async status(token) {
+        return await window.request.get('/api/v1/course-recommendation/status', {
+            params: { token }
+        });
+    },
+
+    // 标记推荐有用
+    async markHelpful(recommendationId) {
+        return await window.request.post('/api/v1/course-recommendation/helpful', {
+            recommendation_id: recommendationId
+        });
+    },
+
+    // 举报推荐
+    async report(recommendationId, reason) {
+        return await window.request.post('/api/v1/course-recommendation/report', {
+            recommendation_id: recommendationId,
+            reason
+        });
+    },
+
     // 格式化时间戳
     formatTime(timestamp) {
         const date = new Date(timestamp * 1000);
@@ -12,6 +12,23 @@
     }
   });
 
+  // 获取 (首次访问时生成) 保存在 localStorage 中的客户端标识
+  function getClientId() {
+    try {
+      let id = localStorage.getItem('client_id');
+      if (!id) {
+        id = window.crypto?.randomUUID
+          ? window.crypto.randomUUID()
+          : Date.now().toString(36) + '-' + Math.random().toString(36).slice(2, 12);
+        localStorage.setItem('client_id', id);
+      }
+      return id;
+    } catch (e) {
+      // 隐私模式下 localStorage 不可用，由服务端退化为按 User-Agent 识别
+      return '';
+    }
+  }
+
   // 请求拦截器
   request.interceptors.request.use(
     (config) => {
@@ -20,6 +37,8 @@
       if (authCookie) {
         config.headers['Authorization'] = authCookie;
       }
+      // 客户端标识，用于匿名接口的限流和去重
+      config.headers['X-Client-ID'] = getClientId();
       return config;
     },
     (error) => {